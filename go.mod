@@ -1,8 +1,9 @@
 module github.com/amsokol/protoc-gen-gotagger
 
-go 1.12
+go 1.16
 
 require (
 	github.com/fatih/structtag v1.0.0
-	github.com/golang/protobuf v1.3.1
+	github.com/golang/protobuf v1.5.2
+	google.golang.org/protobuf v1.27.1
 )
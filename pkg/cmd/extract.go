@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+)
+
+// RunExtract is entrypoint of 'extract' command.
+// It extracts hand-edited tags of generated Go files and emits them as manifest (JSON) or patch for source proto files.
+// Tags the plugin derives automatically by parameter value (see --parameter flag) and by tag providers are not extracted.
+// Descriptor set is built by protoc. It must contain source code info to build the patch.
+// Example:
+// protoc --include_imports --include_source_info --descriptor_set_out=./data.desc ./test/data.proto
+// protoc-gen-gotagger extract --descriptor_set=./data.desc --go=./test --parameter='original_field_names="bson"' --format=patch > tags.patch
+func RunExtract(args []string, providers ...tagger.TagProvider) int {
+	fs := flag.NewFlagSet("extract", flag.ContinueOnError)
+	var (
+		descriptorSet string
+		goPath        string
+		protoPath     string
+		format        string
+		taggerImport  string
+		out           string
+		parameter     string
+	)
+	fs.StringVar(&descriptorSet, "descriptor_set", "", "descriptor set file path (protoc --descriptor_set_out)")
+	fs.StringVar(&goPath, "go", ".", "folder of generated Go files")
+	fs.StringVar(&protoPath, "proto_path", ".", "comma delimited folders of source proto files")
+	fs.StringVar(&format, "format", "manifest", "output format: manifest or patch")
+	fs.StringVar(&taggerImport, "tagger_import", "tagger/tagger.proto", "import path of tagger proto file")
+	fs.StringVar(&out, "out", "", "output file path (std output by default)")
+	fs.StringVar(&parameter, "parameter", "", "'gotagger_out' parameter value the plugin is run with")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if len(descriptorSet) == 0 {
+		log.Print("descriptor set file path is not provided (see --descriptor_set)")
+		return 2
+	}
+	if format != "manifest" && format != "patch" {
+		log.Printf("invalid format '%s', valid values are 'manifest' and 'patch'", format)
+		return 2
+	}
+
+	data, err := ioutil.ReadFile(descriptorSet)
+	if err != nil {
+		log.Printf("failed to read descriptor set file '%s': %s", descriptorSet, err.Error())
+		return 1
+	}
+	set := &descriptor.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		log.Printf("failed to unmarshal descriptor set file '%s': %s", descriptorSet, err.Error())
+		return 1
+	}
+
+	fields, err := tagger.Extract(set, os.DirFS(goPath), parameter, providers...)
+	if err != nil {
+		log.Printf("failed to extract tags: %s", err.Error())
+		return 1
+	}
+
+	var w io.Writer = os.Stdout
+	if len(out) > 0 {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Printf("failed to create output file '%s': %s", out, err.Error())
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch format {
+	case "manifest":
+		if fields == nil {
+			fields = []*tagger.ExtractedField{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(fields); err != nil {
+			log.Printf("failed to write manifest: %s", err.Error())
+			return 1
+		}
+	case "patch":
+		patch, err := tagger.Patch(set, fields, strings.Split(protoPath, ","), taggerImport)
+		if err != nil {
+			log.Printf("failed to build patch: %s", err.Error())
+			return 1
+		}
+		if _, err = fmt.Fprint(w, patch); err != nil {
+			log.Printf("failed to write patch: %s", err.Error())
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+)
+
+// helpParamsFlag is flag to print plugin parameters
+const helpParamsFlag = "--help-params"
+
+// RunHelpParams prints plugin parameters (see tagger.Parameters) as readable text or JSON (provided by format)
+// to output (provided by w).
+// JSON is used by build tools to validate plugin configurations (e.g. buf.gen.yaml).
+// Example:
+// protoc-gen-gotagger --help-params
+// protoc-gen-gotagger --help-params=json
+func RunHelpParams(w io.Writer, format string) int {
+	params := tagger.Parameters()
+
+	switch format {
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+		for _, p := range params {
+			typ := p.Type
+			if p.Repeated {
+				typ += " (repeated)"
+			}
+			desc := p.Description
+			if len(p.Allowed) > 0 {
+				desc += " [" + strings.Join(p.Allowed, "|") + "]"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Name, typ, p.Default, desc)
+		}
+		if err := tw.Flush(); err != nil {
+			log.Printf("failed to write parameters: %s", err.Error())
+			return 1
+		}
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(params); err != nil {
+			log.Printf("failed to write parameters: %s", err.Error())
+			return 1
+		}
+	default:
+		log.Printf("invalid format '%s', valid values are 'text' and 'json'", format)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+func TestRunHelpParams(t *testing.T) {
+	var out bytes.Buffer
+	if code := RunHelpParams(&out, "json"); code != 0 {
+		t.Fatalf("exit code: got %d, want 0", code)
+	}
+	// JSON shape is used by build tools, so it is compared with golden file
+	taggertest.Golden(t, "testdata/help_params.json.golden", out.String())
+
+	var params []tagger.ParameterInfo
+	if err := json.Unmarshal(out.Bytes(), &params); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(params) != len(tagger.Parameters()) {
+		t.Errorf("number of parameters: got %d, want %d", len(params), len(tagger.Parameters()))
+	}
+
+	out.Reset()
+	if code := RunHelpParams(&out, "text"); code != 0 {
+		t.Fatalf("exit code: got %d, want 0", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(params)+1 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Errorf("unexpected text output:\n%s", out.String())
+	}
+	for _, l := range lines {
+		if strings.HasPrefix(l, "generator ") && !strings.HasSuffix(l, "[golang|gogo]") {
+			t.Errorf("allowed values of 'generator' are not printed: %s", l)
+		}
+	}
+
+	if code := RunHelpParams(ioutil.Discard, "yaml"); code != 2 {
+		t.Errorf("exit code of invalid format: got %d, want 2", code)
+	}
+}
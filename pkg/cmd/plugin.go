@@ -1,38 +1,46 @@
 package cmd
 
 import (
-	"flag"
-	"io"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
 )
 
 // Run is tne plugin entrypoint.
-// It may be run in debug mode if '--debug' parameter is provided.
-// Debug mode ignores std input but reads input date from file is provided by --debug parameter.
-// Example how to run in debug mode:
-// protoc-gen-gotagger --debug=./stdin.bin
+// It runs 'extract' command if the first argument is 'extract' (see RunExtract).
+// It runs 'replay' command if the first argument is 'replay' (see RunReplay).
+// Replay is used for debugging: request is captured by 'dump_request' parameter
+// (or GOTAGGER_DUMP_REQUEST environment variable) and replayed by the following command:
+// protoc-gen-gotagger replay ./stdin.bin
+// It prints plugin parameters if the first argument is '--help-params' or '--help-params=json' (see RunHelpParams).
 func Run() int {
-	var debug string
-	flag.StringVar(&debug, "debug", "", "debug input data file path")
-
-	flag.Parse()
+	return RunWith()
+}
 
-	var err error
-	var in io.Reader
-	if len(debug) > 0 {
-		in, err = os.Open(debug)
-		if err != nil {
-			log.Printf("failed to open debug file '%s': %s", debug, err.Error())
-			return 1
+// RunWith is the plugin entrypoint with additional tag providers (see tagger.TagProvider).
+// It is used to build custom plugin binary with company-specific tag providers.
+// Providers are used by 'extract' and 'replay' commands also. Example:
+//
+//	func main() {
+//		os.Exit(cmd.RunWith(&acme.SpannerProvider{}))
+//	}
+func RunWith(providers ...tagger.TagProvider) int {
+	if len(os.Args) > 1 {
+		switch arg := os.Args[1]; {
+		case arg == "extract":
+			return RunExtract(os.Args[2:], providers...)
+		case arg == "replay":
+			return RunReplay(os.Stdout, os.Args[2:], providers...)
+		case arg == helpParamsFlag:
+			return RunHelpParams(os.Stdout, "text")
+		case strings.HasPrefix(arg, helpParamsFlag+"="):
+			return RunHelpParams(os.Stdout, strings.TrimPrefix(arg, helpParamsFlag+"="))
 		}
-	} else {
-		in = os.Stdin
 	}
 
-	p := tagger.NewPlugin(in, os.Stdout)
+	p := tagger.NewPlugin(os.Stdin, os.Stdout, tagger.WithTagProviders(providers...))
 
 	if err := p.Proccess(); err != nil {
 		log.Print(err.Error())
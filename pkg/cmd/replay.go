@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+
+	"github.com/golang/protobuf/proto"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+)
+
+// RunReplay is entrypoint of 'replay' command.
+// It runs captured requests (see 'dump_request' parameter and tagger.DumpRequestEnv) through the plugin
+// and prints decoded responses as readable text or JSON to w.
+// Go files are read relative to the current folder the same way as protoc runs the plugin.
+// Example:
+// protoc-gen-gotagger replay --format=json ./stdin.bin
+func RunReplay(w io.Writer, args []string, providers ...tagger.TagProvider) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	var (
+		format    string
+		parameter string
+	)
+	fs.StringVar(&format, "format", "text", "output format: text or json")
+	fs.StringVar(&parameter, "parameter", "", "parameter value to replace captured one")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() == 0 {
+		log.Print("captured request file paths are not provided")
+		return 2
+	}
+	if format != "text" && format != "json" {
+		log.Printf("invalid format '%s', valid values are 'text' and 'json'", format)
+		return 2
+	}
+
+	// captured parameter is replaced if --parameter flag is provided (even empty one)
+	var replaceParameter bool
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "parameter" {
+			replaceParameter = true
+		}
+	})
+
+	for _, path := range fs.Args() {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Printf("failed to read captured request file '%s': %s", path, err.Error())
+			return 1
+		}
+
+		if replaceParameter {
+			req := &plugin_go.CodeGeneratorRequest{}
+			if err = proto.Unmarshal(data, req); err != nil {
+				log.Printf("failed to unmarshal captured request file '%s': %s", path, err.Error())
+				return 1
+			}
+			req.Parameter = proto.String(parameter)
+			if data, err = proto.Marshal(req); err != nil {
+				log.Printf("failed to marshal request of file '%s': %s", path, err.Error())
+				return 1
+			}
+		}
+
+		var out bytes.Buffer
+		p := tagger.NewPlugin(bytes.NewReader(data), &out, tagger.WithTagProviders(providers...))
+		if err = p.Proccess(); err != nil {
+			log.Printf("failed to replay request of file '%s': %s", path, err.Error())
+			return 1
+		}
+
+		resp := &plugin_go.CodeGeneratorResponse{}
+		if err = proto.Unmarshal(out.Bytes(), resp); err != nil {
+			log.Printf("failed to unmarshal response of file '%s': %s", path, err.Error())
+			return 1
+		}
+
+		if format == "json" {
+			b, err := protojson.MarshalOptions{Multiline: true}.Marshal(proto.MessageV2(resp))
+			if err != nil {
+				log.Printf("failed to marshal response of file '%s' to JSON: %s", path, err.Error())
+				return 1
+			}
+			if _, err = fmt.Fprintf(w, "%s\n", b); err != nil {
+				log.Printf("failed to write response of file '%s': %s", path, err.Error())
+				return 1
+			}
+			continue
+		}
+		if err = printResponse(w, path, resp); err != nil {
+			log.Printf("failed to write response of file '%s': %s", path, err.Error())
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// printResponse prints response of captured request (provided by path) as readable text to w
+func printResponse(w io.Writer, path string, resp *plugin_go.CodeGeneratorResponse) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "=== request: %s\n", path)
+	if len(resp.GetError()) > 0 {
+		fmt.Fprintf(&buf, "error: %s\n", resp.GetError())
+	}
+	for _, f := range resp.GetFile() {
+		fmt.Fprintf(&buf, "--- file: %s\n", f.GetName())
+		buf.WriteString(f.GetContent())
+		if c := f.GetContent(); len(c) > 0 && c[len(c)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
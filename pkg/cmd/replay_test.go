@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+func TestRunReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotagger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "stdin.bin")
+
+	// request is dumped by the plugin run
+	set := taggertest.LoadDescriptorSet(t, "../tagger/testdata/test.protoset")
+	data, err := proto.Marshal(&plugin_go.CodeGeneratorRequest{
+		FileToGenerate: []string{"data.proto"},
+		Parameter:      proto.String("dump_request=" + path + ",output_path=../tagger/testdata,original_field_names=db"),
+		ProtoFile:      set.GetFile(),
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var resp bytes.Buffer
+	if err = tagger.NewPlugin(bytes.NewReader(data), &resp).Proccess(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var out bytes.Buffer
+	if code := RunReplay(&out, []string{path}); code != 0 {
+		t.Fatalf("exit code: got %d, want 0", code)
+	}
+	text := out.String()
+	if !strings.HasPrefix(text, "=== request: "+path+"\n--- file: data.pb.go\n") || !strings.Contains(text, `db:"val_vvall"`) {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+
+	// captured parameter is replaced by --parameter flag
+	out.Reset()
+	if code := RunReplay(&out, []string{"--format=json", "--parameter=output_path=../tagger/testdata,original_field_names=yaml", path}); code != 0 {
+		t.Fatalf("exit code: got %d, want 0", code)
+	}
+	replayed := &plugin_go.CodeGeneratorResponse{}
+	if err = protojson.Unmarshal(out.Bytes(), proto.MessageV2(replayed)); err != nil {
+		t.Fatalf("failed to unmarshal JSON output: %s", err.Error())
+	}
+	if len(replayed.GetFile()) != 1 || !strings.Contains(replayed.GetFile()[0].GetContent(), `yaml:"val_vvall"`) ||
+		strings.Contains(replayed.GetFile()[0].GetContent(), `db:"val_vvall"`) {
+		t.Errorf("unexpected JSON output:\n%s", out.String())
+	}
+
+	if code := RunReplay(&out, []string{"--format=xml", path}); code != 2 {
+		t.Errorf("exit code of invalid format: got %d, want 2", code)
+	}
+}
@@ -0,0 +1,150 @@
+package tagger
+
+import (
+	"fmt"
+	"go/token"
+
+	"github.com/fatih/structtag"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Options are options of Analyze func
+type Options struct {
+	// Parameter contains comma delimited parameters in 'gotagger_out' format
+	// (e.g. xxx="bson+\"-\"",original_field_names="bson,graphql").
+	// CodeGeneratorRequest parameter is not used by Analyze func.
+	// output_path, wrap, wrap_param and dump_request parameters are ignored.
+	Parameter string
+
+	// Providers are tag providers in addition to registered ones (see RegisterTagProvider func)
+	Providers []TagProvider
+}
+
+// TagPlan is map of <Go file name>->tags to apply to Go file.
+// Go file name is base name of Go file is generated for source proto file (e.g. data.pb.go).
+type TagPlan map[string]*FilePlan
+
+// FilePlan contains tags to apply to Go structs of Go file
+type FilePlan struct {
+	// Structs is map of <Go struct name>-><Go field name>->tags to apply to Go struct field
+	Structs map[string]map[string]*FieldPlan
+
+	// RenameKeys is map of <old tag key>-><new tag key> to rename in every field of Go structs.
+	// New tag keys must be unique and must not be renamed also.
+	RenameKeys map[string]string
+
+	// StripKeys contains tag keys to remove from every field of Go structs
+	StripKeys []string
+
+	// Optional is true if Go file may be absent (e.g. target file of other Go plugin)
+	Optional bool
+}
+
+// FieldPlan contains tags to apply to Go struct field
+type FieldPlan struct {
+	// Tags are tags in struct tag format (e.g. bson:"name" graphql:"name,optional").
+	// They are added to the field tags. Existing tags with the same keys are replaced.
+	Tags string
+
+	// Omitempty is map of <tag key>-><true to add, false to remove> 'omitempty' tag option.
+	// It is applied to the field tags after update.
+	Omitempty map[string]bool
+
+	// Optional is true if the field may be absent in Go struct (e.g. XXX_unrecognized).
+	// Apply func fails if other fields are not found.
+	Optional bool
+}
+
+// Analyze analyzes source proto files (provided by CodeGeneratorRequest) and returns tags to apply to generated Go files.
+// It doesn't read or write any file.
+func Analyze(req *plugin_go.CodeGeneratorRequest, opts Options) (TagPlan, error) {
+	p := newPlugin()
+	p.request = req
+	p.providers = append(p.providers, opts.Providers...)
+
+	if err := p.parseParameter(opts.Parameter); err != nil {
+		return nil, fmt.Errorf("failed to parse parameter value: %s", err.Error())
+	}
+	// wrapped plugin is not run, so Go files are named by base names
+	p.wrap = ""
+
+	if err := p.resolveNames(); err != nil {
+		return nil, fmt.Errorf("failed to resolve Go names of source proto files: %s", err.Error())
+	}
+
+	if err := p.analyzeSourceFiles(); err != nil {
+		return nil, fmt.Errorf("failed to analyze source proto files: %s", err.Error())
+	}
+
+	plan := TagPlan{}
+	for name, file := range p.targetFiles {
+		plan[name] = newFilePlan(file, &p.keys)
+	}
+
+	return plan, nil
+}
+
+// Apply applies tags (provided by plan) to Go file source and returns updated Go file source.
+// Only tag literals are changed, other bytes of Go file source are left untouched.
+func Apply(src []byte, plan *FilePlan) ([]byte, error) {
+	file, keys, err := plan.goFile()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := modifyGoFile(token.NewFileSet(), "", src, file, keys, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
+
+// newFilePlan converts data to update Go file to exported FilePlan
+func newFilePlan(file goFile, keys *tagKeys) *FilePlan {
+	fp := &FilePlan{
+		Structs:    map[string]map[string]*FieldPlan{},
+		RenameKeys: map[string]string{},
+		StripKeys:  append([]string(nil), keys.strip...),
+		Optional:   file.optional,
+	}
+	for from, to := range keys.rename {
+		fp.RenameKeys[from] = to
+	}
+
+	for sn, s := range file.structs {
+		fields := map[string]*FieldPlan{}
+		for fn, f := range s {
+			fields[fn] = &FieldPlan{Tags: tagsString(f.tags), Omitempty: f.omitempty, Optional: f.optional}
+		}
+		fp.Structs[sn] = fields
+	}
+
+	return fp
+}
+
+// goFile converts FilePlan to data to update Go file
+func (fp *FilePlan) goFile() (goFile, *tagKeys, error) {
+	file := goFile{structs: map[string]goStruct{}, optional: fp.Optional}
+	keys := &tagKeys{rename: fp.RenameKeys, strip: fp.StripKeys}
+	if err := keys.validate(); err != nil {
+		return goFile{}, nil, fmt.Errorf("invalid rename keys: %s", err.Error())
+	}
+
+	for sn, s := range fp.Structs {
+		gs := goStruct{}
+		for fn, f := range s {
+			tags, err := structtag.Parse(f.Tags)
+			if err != nil {
+				return goFile{}, nil, fmt.Errorf("failed to parse tags '%s' of field '%s' of struct '%s': %s", f.Tags, fn, sn, err.Error())
+			}
+			if tags == nil {
+				tags = &structtag.Tags{}
+			}
+			gs[fn] = &goField{tags: tags, omitempty: f.Omitempty, optional: f.Optional}
+		}
+		file.structs[sn] = gs
+	}
+
+	return file, keys, nil
+}
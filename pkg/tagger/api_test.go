@@ -0,0 +1,57 @@
+package tagger_test
+
+import (
+	"strings"
+	"testing"
+
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+func TestAnalyzeApply(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	src := goFixture(t, "data.pb.go")
+	parameter := `xxx=bson+"-",original_field_names="bson,graphql",comment_tags=doc`
+
+	req := &plugin_go.CodeGeneratorRequest{FileToGenerate: []string{"data.proto"}, ProtoFile: set.GetFile()}
+	plan, err := tagger.Analyze(req, tagger.Options{Parameter: parameter})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(plan) != 1 || plan["data.pb.go"] == nil {
+		t.Fatalf("expected plan of 'data.pb.go' only, got: %v", plan)
+	}
+
+	got, err := tagger.Apply([]byte(src), plan["data.pb.go"])
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// library API must produce the same Go file as plugin
+	files, err := taggertest.Process(&taggertest.Case{
+		Set:       set,
+		Generate:  []string{"data.proto"},
+		Parameter: parameter,
+		Go:        map[string]string{"data.pb.go": src},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(got) != files["data.pb.go"] {
+		t.Errorf("Go file updated by Apply differs from plugin one:\n%s", got)
+	}
+
+	if _, err = tagger.Apply([]byte(src), &tagger.FilePlan{
+		Structs: map[string]map[string]*tagger.FieldPlan{"Data": {"Unknown": {Tags: `bson:"unknown"`}}},
+	}); err == nil {
+		t.Error("expected error for unknown field, got none")
+	}
+
+	if _, err = tagger.Apply([]byte(src), &tagger.FilePlan{
+		RenameKeys: map[string]string{"graphql": "gql", "gql": "graphql"},
+	}); err == nil || !strings.Contains(err.Error(), "invalid rename keys") {
+		t.Errorf("expected invalid rename keys error, got: %v", err)
+	}
+}
@@ -0,0 +1,704 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Field numbers are used to build location paths of options in source proto file.
+// See descriptor.SourceCodeInfo_Location for details.
+const (
+	// fieldNumberPath is FieldDescriptorProto.number field number
+	fieldNumberPath = 3
+	// fieldOptionsPath is FieldDescriptorProto.options field number
+	fieldOptionsPath = 8
+	// oneofOptionsPath is OneofDescriptorProto.options field number
+	oneofOptionsPath = 2
+	// packagePath is FileDescriptorProto.package field number
+	packagePath = 2
+	// dependencyPath is FileDescriptorProto.dependency field number
+	dependencyPath = 3
+	// syntaxPath is FileDescriptorProto.syntax field number
+	syntaxPath = 12
+)
+
+// generatedTagKeys are tag keys are generated by protoc-gen-go. They are never extracted.
+var generatedTagKeys = map[string]bool{
+	"protobuf":       true,
+	"protobuf_key":   true,
+	"protobuf_val":   true,
+	"protobuf_oneof": true,
+}
+
+// ExtractedField contains hand-edited tags of Go struct field are generated for proto field (oneof).
+// Such tags are lost on regeneration unless they are moved to proto annotations.
+type ExtractedField struct {
+	// File is source proto file name
+	File string `json:"file"`
+
+	// Field is full proto field (oneof) name (e.g. test.Data.val_vvall)
+	Field string `json:"field"`
+
+	// Oneof is true if Field is oneof
+	Oneof bool `json:"oneof,omitempty"`
+
+	// Tags are hand-edited tags in (tagger.tags) option format
+	Tags string `json:"tags"`
+
+	// path is field (oneof) location path in source proto file
+	path []int32
+
+	// explicit are tags are provided by (tagger.tags) or (tagger.oneof_tags) option already
+	explicit *structtag.Tags
+}
+
+// Extract scans Go files (are located in root of goFS file system) are generated for proto files (provided by set)
+// and returns Go struct field tags are not generated by protoc-gen-go and are not provided by proto annotations.
+// Parameter is the same 'gotagger_out' parameter value the plugin is run with (e.g. original_field_names="bson"),
+// so tags the plugin derives automatically (original_field_names, comment_tags, number_tags, json=protojson, etc.)
+// are not extracted. Tags of providers (in addition to registered ones, see RegisterTagProvider func)
+// are not extracted also, so they should be the same providers the plugin is run with.
+// It maps Go structs and fields back to proto messages and fields using the same naming rules as the plugin.
+func Extract(set *descriptor.FileDescriptorSet, goFS fs.FS, parameter string, providers ...TagProvider) ([]*ExtractedField, error) {
+	p := newPlugin()
+	p.request = &plugin_go.CodeGeneratorRequest{ProtoFile: set.GetFile()}
+	p.providers = append(p.providers, providers...)
+
+	if err := p.parseParameter(parameter); err != nil {
+		return nil, fmt.Errorf("failed to parse parameter value: %s", err.Error())
+	}
+	// Go files are located in root of goFS, so they are named by base names even if plugin wraps other plugin
+	p.wrap = ""
+
+	if err := p.resolveNames(); err != nil {
+		return nil, fmt.Errorf("failed to resolve Go names: %s", err.Error())
+	}
+
+	var fields []*ExtractedField
+	for _, f := range set.GetFile() {
+		if f.GetSyntax() != "proto3" {
+			continue
+		}
+
+		path := p.getGoFileName(f)
+		if _, err := fs.Stat(goFS, path); errors.Is(err, fs.ErrNotExist) {
+			// Go file is not generated for dependency
+			continue
+		}
+		src, err := readGoFile(goFS, path)
+		if err != nil {
+			return nil, err
+		}
+
+		structs, err := parseStructTags(path, src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Go file '%s': %s", path, err.Error())
+		}
+
+		// tags the plugin applies to the Go file are not extracted
+		if err := p.analyzeFile(f); err != nil {
+			return nil, fmt.Errorf("failed to analyze proto file '%s': %s", f.GetName(), err.Error())
+		}
+
+		file := &protoFile{desc: f, target: p.targetFiles[path]}
+		for i, m := range f.GetMessageType() {
+			ef, err := p.extractMessageType(file, structs, []string{}, []int32{messageTypePath, int32(i)}, m)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract tags of message type '%s': %s", m.GetName(), err.Error())
+			}
+			fields = append(fields, ef...)
+		}
+	}
+
+	return fields, nil
+}
+
+// extractMessageType returns hand-edited tags of fields and oneofs of proto message.
+// It drills down into nested proto Messages also.
+func (p *plugin) extractMessageType(file *protoFile, structs map[string]map[string]*structtag.Tags,
+	parents []string, path []int32, message *descriptor.DescriptorProto) ([]*ExtractedField, error) {
+	goMes, err := p.names.structName(p.getMessageFullName(file, parents, message.GetName()))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []*ExtractedField
+	add := func(name string, goStruct string, goField string, oneof bool, path []int32, opts proto.Message,
+		exts tagExtensions) error {
+		tags := structs[goStruct][goField]
+		if tags == nil {
+			return nil
+		}
+
+		explicit, known, err := p.getAnnotatedTags(opts, exts)
+		if err != nil {
+			return fmt.Errorf("failed to get tags of '%s': %s", name, err.Error())
+		}
+		planned := plannedTags(file.target.structs[goStruct][goField])
+
+		extra := &structtag.Tags{}
+		for _, t := range tags.Tags() {
+			if generatedTagKeys[t.Key] || known[t.Key] {
+				continue
+			}
+			if pt, err := planned.Get(t.Key); err == nil && pt.Value() == t.Value() {
+				// tag is applied by the plugin
+				continue
+			}
+			if t.Key == "json" && t.Value() == name+",omitempty" {
+				// default json tag is generated by protoc-gen-go
+				continue
+			}
+			extra.Set(t)
+		}
+
+		if extra.Len() > 0 {
+			fields = append(fields, &ExtractedField{
+				File:     file.desc.GetName(),
+				Field:    p.getFieldURI(file, parents, message.GetName(), name),
+				Oneof:    oneof,
+				Tags:     tagsString(extra),
+				path:     path,
+				explicit: explicit,
+			})
+		}
+
+		return nil
+	}
+
+	for i, field := range message.GetField() {
+		n, err := p.names.fieldName(p.getFieldURI(file, parents, message.GetName(), field.GetName()))
+		if err != nil {
+			return nil, err
+		}
+		s := goMes
+		if wrapper, ok := p.names.wrappers[p.getFieldURI(file, parents, message.GetName(), field.GetName())]; ok {
+			s = wrapper
+		}
+		if err := add(field.GetName(), s, n, false, locationPath(path, fieldPath, i),
+			field.GetOptions(), fieldExtensions); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, oneOf := range message.GetOneofDecl() {
+		if p.isSyntheticOneof(message, i) {
+			continue
+		}
+		n, err := p.names.fieldName(p.getFieldURI(file, parents, message.GetName(), oneOf.GetName()))
+		if err != nil {
+			return nil, err
+		}
+		if err := add(oneOf.GetName(), goMes, n, true, locationPath(path, oneofDeclPath, i),
+			oneOf.GetOptions(), oneofExtensions); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, m := range message.GetNestedType() {
+		ps := make([]string, len(parents), len(parents)+1)
+		copy(ps, parents)
+		ps = append(ps, message.GetName())
+		ef, err := p.extractMessageType(file, structs, ps, locationPath(path, nestedTypePath, i), m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tags of message type '%s': %s", p.getMessageURI(ps, m.GetName()), err.Error())
+		}
+		fields = append(fields, ef...)
+	}
+
+	return fields, nil
+}
+
+// getAnnotatedTags returns tags are provided by (tagger.tags) or (tagger.oneof_tags) option
+// and set of tag keys are provided by any tag annotation of field (oneof).
+func (p *plugin) getAnnotatedTags(opts proto.Message, exts tagExtensions) (*structtag.Tags, map[string]bool, error) {
+	explicit := &structtag.Tags{}
+	known := map[string]bool{}
+
+	ext, err := p.getExtension(opts, exts.tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := p.getStructuredTags(opts, exts.field)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tags, err := structtag.Parse(ext); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse tags '%s': %s", ext, err.Error())
+	} else if tags != nil {
+		explicit = tags
+	}
+
+	for _, k := range explicit.Keys() {
+		known[k] = true
+	}
+	for _, f := range fields {
+		for _, t := range f.GetTag() {
+			known[t.GetKey()] = true
+		}
+		for _, k := range f.GetHide() {
+			known[k] = true
+		}
+	}
+
+	return explicit, known, nil
+}
+
+// plannedTags returns tags the plugin applies to Go struct field (provided by plan) with 'omitempty' policy applied.
+// It returns empty tags if the plugin doesn't update the field.
+func plannedTags(field *goField) *structtag.Tags {
+	tags := &structtag.Tags{}
+	if field == nil || field.tags == nil {
+		return tags
+	}
+
+	for _, t := range field.tags.Tags() {
+		tags.Set(cloneTag(t))
+	}
+	for k, add := range field.omitempty {
+		if t, err := tags.Get(k); err != nil || t.Name == "-" {
+			continue
+		}
+		if add {
+			tags.AddOptions(k, "omitempty")
+		} else {
+			tags.DeleteOptions(k, "omitempty")
+		}
+	}
+
+	return tags
+}
+
+// parseStructTags parses Go file and returns map of <struct name>-><field name>->tags
+func parseStructTags(path string, src []byte) (map[string]map[string]*structtag.Tags, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := map[string]map[string]*structtag.Tags{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+		tp, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := tp.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		fields := map[string]*structtag.Tags{}
+		for _, fld := range st.Fields.List {
+			name := fieldName(fld)
+			if len(name) == 0 || fld.Tag == nil {
+				continue
+			}
+			var tag string
+			if tag, err = strconv.Unquote(fld.Tag.Value); err != nil {
+				err = fmt.Errorf("failed to unquote tags %s of field '%s': %s", fld.Tag.Value, name, err.Error())
+				return false
+			}
+			var tags *structtag.Tags
+			if tags, err = structtag.Parse(tag); err != nil {
+				err = fmt.Errorf("failed to parse tags '%s' of field '%s': %s", tag, name, err.Error())
+				return false
+			}
+			if tags != nil {
+				fields[name] = tags
+			}
+		}
+		structs[tp.Name.String()] = fields
+
+		return false
+	})
+
+	return structs, err
+}
+
+// Patch returns unified diff for source proto files (are located in one of protoPaths folders)
+// that adds (tagger.tags) and (tagger.oneof_tags) options with extracted tags.
+// Tags are merged into existing options. Import of tagger proto file (provided by taggerImport) is added if necessary.
+// Descriptor set must contain source code info (see protoc --include_source_info flag).
+func Patch(set *descriptor.FileDescriptorSet, fields []*ExtractedField, protoPaths []string, taggerImport string) (string, error) {
+	files := map[string][]*ExtractedField{}
+	for _, f := range fields {
+		files[f.File] = append(files[f.File], f)
+	}
+
+	var diff strings.Builder
+	for _, f := range set.GetFile() {
+		ef := files[f.GetName()]
+		if len(ef) == 0 {
+			continue
+		}
+
+		if f.GetSourceCodeInfo() == nil {
+			return "", fmt.Errorf("descriptor set has no source code info for '%s', use --include_source_info protoc flag", f.GetName())
+		}
+
+		var src []byte
+		var err error
+		for _, dir := range protoPaths {
+			if src, err = ioutil.ReadFile(filepath.Join(dir, f.GetName())); err == nil {
+				break
+			}
+		}
+		if src == nil {
+			return "", fmt.Errorf("failed to find source proto file '%s' in proto paths %v", f.GetName(), protoPaths)
+		}
+
+		edits, err := patchEdits(f, ef, string(src), taggerImport)
+		if err != nil {
+			return "", fmt.Errorf("failed to patch source proto file '%s': %s", f.GetName(), err.Error())
+		}
+
+		diff.WriteString(unifiedDiff(f.GetName(), string(src), edits))
+	}
+
+	return diff.String(), nil
+}
+
+// textEdit replaces text between start and end byte offsets
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// patchEdits returns text edits of source proto file to add extracted tags
+func patchEdits(f *descriptor.FileDescriptorProto, fields []*ExtractedField, src string, taggerImport string) ([]textEdit, error) {
+	locations := map[string]*descriptor.SourceCodeInfo_Location{}
+	for _, l := range f.GetSourceCodeInfo().GetLocation() {
+		locations[locationKey(l.GetPath())] = l
+	}
+
+	lines := strings.SplitAfter(src, "\n")
+	offset := func(line, col int32) int {
+		o := 0
+		for i := 0; i < int(line) && i < len(lines); i++ {
+			o += len(lines[i])
+		}
+		// protoc counts columns with tabs expanded to 8 characters
+		var c int32
+		for i := 0; i < len(lines[line]); i++ {
+			if c >= col {
+				return o + i
+			}
+			if lines[line][i] == '\t' {
+				c += 8 - c%8
+			} else {
+				c++
+			}
+		}
+		return o + len(lines[line])
+	}
+	span := func(path []int32) (int, int, bool) {
+		l := locations[locationKey(path)]
+		if l == nil || len(l.GetSpan()) < 3 {
+			return 0, 0, false
+		}
+		s := l.GetSpan()
+		if len(s) == 3 {
+			return offset(s[0], s[1]), offset(s[0], s[2]), true
+		}
+		return offset(s[0], s[1]), offset(s[2], s[3]), true
+	}
+
+	var edits []textEdit
+	for _, ef := range fields {
+		start, end, ok := span(ef.path)
+		if !ok {
+			return nil, fmt.Errorf("failed to find location of '%s'", ef.Field)
+		}
+
+		extra, err := structtag.Parse(ef.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse extracted tags '%s': %s", ef.Tags, err.Error())
+		}
+
+		optionPath := locationPath(ef.path, fieldOptionsPath, int(fieldExtensions.tags.Field))
+		if ef.Oneof {
+			optionPath = locationPath(ef.path, oneofOptionsPath, int(oneofExtensions.tags.Field))
+		}
+
+		if ef.explicit.Len() > 0 {
+			// merge extracted tags into existing option value
+			os, oe, ok := span(optionPath)
+			if !ok {
+				return nil, fmt.Errorf("failed to find tags option location of '%s'", ef.Field)
+			}
+			merged := &structtag.Tags{}
+			for _, t := range ef.explicit.Tags() {
+				merged.Set(t)
+			}
+			for _, t := range extra.Tags() {
+				merged.Set(t)
+			}
+			text := src[os:oe]
+			eq := strings.Index(text, "=")
+			if eq < 0 {
+				return nil, fmt.Errorf("failed to parse tags option '%s' of '%s'", text, ef.Field)
+			}
+			ve := len(text)
+			if strings.HasSuffix(strings.TrimSpace(text), ";") {
+				ve = strings.LastIndex(text, ";")
+			}
+			edits = append(edits, textEdit{start: os + eq + 1, end: os + ve, text: " " + strconv.Quote(tagsString(merged))})
+			continue
+		}
+
+		value := strconv.Quote(ef.Tags)
+		text := src[start:end]
+		switch {
+		case ef.Oneof:
+			// add option statement into the oneof body
+			i := strings.Index(text, "{")
+			if i < 0 {
+				return nil, fmt.Errorf("failed to find body of oneof '%s'", ef.Field)
+			}
+			// use indentation of the next line of oneof body
+			body := text[i+1:]
+			if nl := strings.Index(body, "\n"); nl >= 0 {
+				body = body[nl+1:]
+			}
+			indent := body[:len(body)-len(strings.TrimLeft(body, " \t"))]
+			edits = append(edits, textEdit{start: start + i + 1, end: start + i + 1,
+				text: "\n" + indent + "option (tagger.oneof_tags) = " + value + ";"})
+		default:
+			// options list follows field number (e.g. 'string name = 1 [json_name = "n"];')
+			_, number, ok := span(append(append([]int32(nil), ef.path...), fieldNumberPath))
+			if !ok {
+				return nil, fmt.Errorf("failed to find number location of '%s'", ef.Field)
+			}
+			if open, close := optionsList(src[:end], number); close >= 0 {
+				line := src[strings.LastIndex(src[:close], "\n")+1 : close]
+				if len(strings.TrimSpace(line)) > 0 {
+					edits = append(edits, textEdit{start: close, end: close, text: ", (tagger.tags) = " + value})
+					continue
+				}
+				// closing bracket of multiline options list is on its own line,
+				// so option is added as the first one with indentation of the next line
+				body := src[open+1 : close]
+				if nl := strings.Index(body, "\n"); nl >= 0 {
+					body = body[nl+1:]
+				}
+				indent := body[:len(body)-len(strings.TrimLeft(body, " \t"))]
+				edits = append(edits, textEdit{start: open + 1, end: open + 1,
+					text: "\n" + indent + "(tagger.tags) = " + value + ","})
+				continue
+			}
+			i := strings.LastIndex(text, ";")
+			if i < 0 {
+				i = len(text)
+			}
+			edits = append(edits, textEdit{start: start + i, end: start + i, text: " [(tagger.tags) = " + value + "]"})
+		}
+	}
+
+	var imported bool
+	for _, d := range f.GetDependency() {
+		imported = imported || d == taggerImport
+	}
+	if !imported {
+		// add import after the last import, package or syntax statement
+		at, text := -1, "\nimport \""+taggerImport+"\";"
+		for i := range f.GetDependency() {
+			if _, end, ok := span([]int32{dependencyPath, int32(i)}); ok && end > at {
+				at = end
+			}
+		}
+		if at < 0 {
+			text = "\n" + text
+			if _, end, ok := span([]int32{packagePath}); ok {
+				at = end
+			} else if _, end, ok := span([]int32{syntaxPath}); ok {
+				at = end
+			} else {
+				at = 0
+			}
+		}
+		edits = append(edits, textEdit{start: at, end: at, text: text})
+	}
+
+	return edits, nil
+}
+
+// optionsList returns offsets of opening and closing brackets of field options list (e.g. '[json_name = "n"]')
+// if the list is started at offset (provided by 'at') after whitespaces. It returns -1 if there is no options list.
+// Brackets of string literals, comments and nested aggregate values are skipped.
+func optionsList(src string, at int) (int, int) {
+	open := len(src) - len(strings.TrimLeft(src[at:], " \t\r\n"))
+	if open == len(src) || src[open] != '[' {
+		return -1, -1
+	}
+
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '"' || c == '\'':
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+		case strings.HasPrefix(src[i:], "//"):
+			nl := strings.IndexByte(src[i:], '\n')
+			if nl < 0 {
+				return -1, -1
+			}
+			i += nl
+		case strings.HasPrefix(src[i:], "/*"):
+			e := strings.Index(src[i+2:], "*/")
+			if e < 0 {
+				return -1, -1
+			}
+			i += e + 3
+		case c == '[':
+			depth++
+		case c == ']':
+			if depth--; depth == 0 {
+				return open, i
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+// unifiedDiff applies text edits to source file and returns unified diff of the changes
+func unifiedDiff(name string, src string, edits []textEdit) string {
+	if len(edits) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	lines := strings.SplitAfter(src, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	starts := make([]int, len(lines)+1)
+	for i, l := range lines {
+		starts[i+1] = starts[i] + len(l)
+	}
+	lineOf := func(offset int) int {
+		i := sort.Search(len(lines), func(i int) bool { return starts[i+1] > offset })
+		if i == len(lines) {
+			i--
+		}
+		return i
+	}
+
+	// change replaces original lines [from, to] by new lines
+	type change struct {
+		from, to int
+		edits    []textEdit
+		lines    []string
+	}
+	var changes []*change
+	for _, e := range edits {
+		from, to := lineOf(e.start), lineOf(e.end)
+		// edits of the same lines are joined (changes of adjacent lines are joined into one hunk)
+		if n := len(changes); n > 0 && changes[n-1].to >= from {
+			c := changes[n-1]
+			c.edits = append(c.edits, e)
+			if to > c.to {
+				c.to = to
+			}
+			continue
+		}
+		changes = append(changes, &change{from: from, to: to, edits: []textEdit{e}})
+	}
+	for _, c := range changes {
+		text := src[starts[c.from]:starts[c.to+1]]
+		for i := len(c.edits) - 1; i >= 0; i-- {
+			e := c.edits[i]
+			text = text[:e.start-starts[c.from]] + e.text + text[e.end-starts[c.from]:]
+		}
+		c.lines = strings.SplitAfter(text, "\n")
+		if c.lines[len(c.lines)-1] == "" {
+			c.lines = c.lines[:len(c.lines)-1]
+		}
+		// lines are not changed by edits (e.g. line before inserted text) are kept as context lines
+		for c.from <= c.to && len(c.lines) > 0 && c.lines[0] == lines[c.from] {
+			c.lines = c.lines[1:]
+			c.from++
+		}
+		for c.from <= c.to && len(c.lines) > 0 && c.lines[len(c.lines)-1] == lines[c.to] {
+			c.lines = c.lines[:len(c.lines)-1]
+			c.to--
+		}
+	}
+
+	const context = 3
+	var out strings.Builder
+	out.WriteString("--- a/" + name + "\n+++ b/" + name + "\n")
+
+	delta := 0
+	for i := 0; i < len(changes); {
+		// hunk contains changes are closer than 2*context lines
+		j := i
+		for j+1 < len(changes) && changes[j+1].from-changes[j].to <= 2*context+1 {
+			j++
+		}
+
+		from := changes[i].from - context
+		if from < 0 {
+			from = 0
+		}
+		to := changes[j].to + context
+		if to >= len(lines) {
+			to = len(lines) - 1
+		}
+
+		var body strings.Builder
+		write := func(prefix string, l string) {
+			body.WriteString(prefix + l)
+			if !strings.HasSuffix(l, "\n") {
+				body.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		oldCount, newCount := to-from+1, to-from+1
+		line := from
+		for _, c := range changes[i : j+1] {
+			for ; line < c.from; line++ {
+				write(" ", lines[line])
+			}
+			for ; line <= c.to; line++ {
+				write("-", lines[line])
+			}
+			for _, l := range c.lines {
+				write("+", l)
+			}
+			newCount += len(c.lines) - (c.to - c.from + 1)
+		}
+		for ; line <= to; line++ {
+			write(" ", lines[line])
+		}
+
+		out.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", from+1, oldCount, from+1+delta, newCount))
+		out.WriteString(body.String())
+		delta += newCount - oldCount
+
+		i = j + 1
+	}
+
+	return out.String()
+}
@@ -0,0 +1,144 @@
+package tagger_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+func TestExtract(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/extract/extract.protoset")
+	goFS := os.DirFS("testdata/extract")
+	protoPaths := []string{"testdata/extract", "../../proto"}
+
+	cases := []struct {
+		name      string
+		parameter string
+		// fields are expected extracted fields (<full proto field name>-><tags>)
+		fields map[string]string
+	}{
+		{
+			name: "hand-edited tags",
+			fields: map[string]string{
+				"test.Extract.plain":            `bson:"plain" yaml:"plain" doc:"plain is field without options."`,
+				"test.Extract.renamed":          `bson:"renamed"`,
+				"test.Extract.deprecated":       `bson:"dep"`,
+				"test.Extract.annotated":        `bson:"annotated"`,
+				"test.Extract.aggregate":        `bson:"aggregate"`,
+				"test.Extract.tabbed":           `json:"tabbed,string,omitempty" bson:"tabbed" msgpack:"6"`,
+				"test.Extract.choice":           `bson:"choice"`,
+				"test.Extract.annotated_choice": `bson:"annotated_choice"`,
+				"test.Extract.a":                `bson:"a" yaml:"a\"quoted\""`,
+				"test.Extract.Nested.value":     `bson:"value"`,
+				"test.NoImport.value":           `bson:"value_custom"`,
+			},
+		},
+		{
+			name:      "derived tags are not extracted",
+			parameter: `original_field_names="bson",comment_tags="doc",number_tags="msgpack",json=protojson`,
+			fields: map[string]string{
+				"test.Extract.plain":      `yaml:"plain"`,
+				"test.Extract.deprecated": `bson:"dep"`,
+				"test.Extract.a":          `yaml:"a\"quoted\""`,
+				"test.NoImport.value":     `bson:"value_custom"`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			fields, err := tagger.Extract(set, goFS, c.parameter)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			got := map[string]string{}
+			for _, f := range fields {
+				got[f.Field] = f.Tags
+			}
+			for name, tags := range c.fields {
+				if got[name] != tags {
+					t.Errorf("tags of '%s':\n\tgot:  `%s`\n\twant: `%s`", name, got[name], tags)
+				}
+			}
+			for name, tags := range got {
+				if _, ok := c.fields[name]; !ok {
+					t.Errorf("unexpected tags of '%s': `%s`", name, tags)
+				}
+			}
+
+			golden := filepath.Join("testdata", "golden", "extract", strings.ReplaceAll(c.name, " ", "_"))
+
+			manifest, err := json.MarshalIndent(fields, "", "  ")
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			taggertest.Golden(t, golden+".json.golden", string(manifest)+"\n")
+
+			patch, err := tagger.Patch(set, fields, protoPaths, "tagger/tagger.proto")
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			taggertest.Golden(t, golden+".patch.golden", patch)
+		})
+	}
+}
+
+func TestExtractErrors(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/extract/extract.protoset")
+	goFS := os.DirFS("testdata/extract")
+
+	if _, err := tagger.Extract(set, goFS, "original_fields_names=bson"); err == nil ||
+		!strings.Contains(err.Error(), "did you mean 'original_field_names'?") {
+		t.Errorf("expected unknown parameter error, got: %v", err)
+	}
+
+	fields, err := tagger.Extract(set, goFS, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err = tagger.Patch(set, fields, []string{"testdata"}, "tagger/tagger.proto"); err == nil ||
+		!strings.Contains(err.Error(), "failed to find source proto file 'extract.proto'") {
+		t.Errorf("expected missing source proto file error, got: %v", err)
+	}
+}
+
+// yamlProvider derives yaml tags from proto field names
+type yamlProvider struct{}
+
+func (yamlProvider) Tags(target *tagger.TagTarget) (string, error) {
+	if target.Field == nil {
+		return "", nil
+	}
+	return `yaml:"` + target.Field.GetName() + `"`, nil
+}
+
+func TestExtractProviders(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/extract/extract.protoset")
+
+	fields, err := tagger.Extract(set, os.DirFS("testdata/extract"), "", yamlProvider{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	got := map[string]string{}
+	for _, f := range fields {
+		got[f.Field] = f.Tags
+	}
+	// provider tags are not extracted, other tags with the same key are
+	want := map[string]string{
+		"test.Extract.plain": `bson:"plain" doc:"plain is field without options."`,
+		"test.Extract.a":     `bson:"a" yaml:"a\"quoted\""`,
+	}
+	for name, tags := range want {
+		if got[name] != tags {
+			t.Errorf("tags of '%s':\n\tgot:  `%s`\n\twant: `%s`", name, got[name], tags)
+		}
+	}
+}
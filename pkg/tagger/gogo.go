@@ -0,0 +1,296 @@
+package tagger
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Supported Go code generators (see 'generator' parameter)
+const (
+	// generatorGolang is protoc-gen-go (github.com/golang/protobuf, google.golang.org/protobuf)
+	generatorGolang = "golang"
+	// generatorGogo is protoc-gen-gogo* (github.com/gogo/protobuf)
+	generatorGogo = "gogo"
+)
+
+// gogoproto option field numbers.
+// See https://github.com/gogo/protobuf/blob/master/gogoproto/gogo.proto for details.
+// gogoproto extensions are registered in gogo/protobuf registry, so they are read from unknown fields of options.
+const (
+	gogoUnrecognizedAll protowire.Number = 63026
+	gogoProtoSizerAll   protowire.Number = 63028
+	gogoSizecacheAll    protowire.Number = 63034
+	gogoUnkeyedAll      protowire.Number = 63035
+
+	gogoUnrecognized protowire.Number = 64026
+	gogoProtoSizer   protowire.Number = 64028
+	gogoSizecache    protowire.Number = 64034
+	gogoUnkeyed      protowire.Number = 64035
+
+	gogoEmbed      protowire.Number = 65002
+	gogoCustomName protowire.Number = 65004
+)
+
+// gogoMethodNames are names of methods are generated by protoc-gen-gogo for every message.
+// Go fields with the same names get '_' suffix.
+var gogoMethodNames = []string{
+	"Reset",
+	"String",
+	"ProtoMessage",
+	"Marshal",
+	"Unmarshal",
+	"ExtensionRangeArray",
+	"ExtensionMap",
+	"Descriptor",
+	"MarshalTo",
+	"Equal",
+	"VerboseEqual",
+	"GoString",
+	"ProtoSize",
+}
+
+// newGogoNames resolves Go names for proto messages, fields and oneofs of proto files
+// the same way as protoc-gen-gogo does. It supports the following gogoproto options:
+// customname - Go field name is set by option
+// embed - message field is embedded, so Go field name is equal to Go type name
+func newGogoNames(files []*descriptor.FileDescriptorProto) (*goNames, error) {
+	names := &goNames{
+		structs:  map[string]string{},
+		fields:   map[string]string{},
+		wrappers: map[string]string{},
+	}
+
+	// struct names are resolved first because embedded field names are equal to struct names
+	for _, f := range files {
+		names.addGogoStructs(f.GetPackage(), []string{}, f.GetMessageType())
+	}
+	for _, f := range files {
+		names.addGogoFields(f, f.GetPackage(), []string{}, f.GetMessageType())
+	}
+
+	return names, nil
+}
+
+// addGogoStructs stores Go struct names of proto messages (including nested ones)
+func (n *goNames) addGogoStructs(pkg string, parents []string, messages []*descriptor.DescriptorProto) {
+	for _, m := range messages {
+		ps := make([]string, len(parents), len(parents)+1)
+		copy(ps, parents)
+		ps = append(ps, m.GetName())
+
+		n.structs[gogoFullName(pkg, ps)] = gogoCamelCase(strings.Join(ps, "_"))
+		n.addGogoStructs(pkg, ps, m.GetNestedType())
+	}
+}
+
+// addGogoFields stores Go names of fields and oneofs of proto messages (including nested ones).
+// It repeats field name conflict resolution of protoc-gen-gogo.
+func (n *goNames) addGogoFields(f *descriptor.FileDescriptorProto, pkg string, parents []string, messages []*descriptor.DescriptorProto) {
+	for _, m := range messages {
+		ps := make([]string, len(parents), len(parents)+1)
+		copy(ps, parents)
+		ps = append(ps, m.GetName())
+		message := gogoFullName(pkg, ps)
+		goMes := n.structs[message]
+
+		used := map[string]bool{}
+		for _, s := range gogoMethodNames {
+			used[s] = true
+		}
+		if !gogoBool(m.GetOptions(), gogoProtoSizer, gogoBool(f.GetOptions(), gogoProtoSizerAll, false)) {
+			used["Size"] = true
+		}
+		// alloc finds conflict-free variation of field and getter names
+		alloc := func(name string) string {
+			for used[name] || used["Get"+name] {
+				name += "_"
+			}
+			used[name] = true
+			used["Get"+name] = true
+			return name
+		}
+
+		nested := map[string]bool{}
+		for _, d := range m.GetNestedType() {
+			nested[n.structs[message+"."+d.GetName()]] = true
+		}
+		for _, e := range m.GetEnumType() {
+			nested[gogoCamelCase(strings.Join(append(ps[:len(ps):len(ps)], e.GetName()), "_"))] = true
+		}
+
+		oneofs := map[int32]bool{}
+		for _, field := range m.GetField() {
+			name := gogoCamelCase(field.GetName())
+			if s, ok := gogoString(field.GetOptions(), gogoCustomName); ok {
+				name = s
+			}
+			name = alloc(name)
+			if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE && gogoBool(field.GetOptions(), gogoEmbed, false) {
+				// embedded field has no name, so it is matched by Go type name
+				name = n.structs[strings.TrimPrefix(field.GetTypeName(), ".")]
+			}
+			n.fields[message+"."+field.GetName()] = name
+
+			if field.OneofIndex == nil || field.GetProto3Optional() {
+				continue
+			}
+			if i := field.GetOneofIndex(); !oneofs[i] {
+				oneofs[i] = true
+				o := m.GetOneofDecl()[i].GetName()
+				n.fields[message+"."+o] = alloc(gogoCamelCase(o))
+			}
+			wrapper := goMes + "_" + name
+			for nested[wrapper] {
+				wrapper += "_"
+			}
+			n.wrappers[message+"."+field.GetName()] = wrapper
+		}
+
+		n.addGogoFields(f, pkg, ps, m.GetNestedType())
+	}
+}
+
+// hasGogoXXX returns true if Go struct of proto message has XXX field (provided by name).
+// gogoproto options may remove XXX fields from Go structs.
+func hasGogoXXX(f *descriptor.FileDescriptorProto, m *descriptor.DescriptorProto, name string) bool {
+	var all, num protowire.Number
+	switch name {
+	case "XXX_NoUnkeyedLiteral":
+		all, num = gogoUnkeyedAll, gogoUnkeyed
+	case "XXX_unrecognized":
+		all, num = gogoUnrecognizedAll, gogoUnrecognized
+	case "XXX_sizecache":
+		all, num = gogoSizecacheAll, gogoSizecache
+	default:
+		return false
+	}
+	return gogoBool(m.GetOptions(), num, gogoBool(f.GetOptions(), all, true))
+}
+
+// gogoFullName returns full proto message name by package and message names
+func gogoFullName(pkg string, names []string) string {
+	if len(pkg) > 0 {
+		return pkg + "." + strings.Join(names, ".")
+	}
+	return strings.Join(names, ".")
+}
+
+// gogoBool returns value of boolean gogoproto option (provided by field number) or default value if option is not set
+func gogoBool(opts proto.Message, num protowire.Number, def bool) bool {
+	v, _, ok := getUnknownOption(opts, num)
+	if !ok {
+		return def
+	}
+	return v != 0
+}
+
+// gogoString returns value of string gogoproto option (provided by field number)
+func gogoString(opts proto.Message, num protowire.Number) (string, bool) {
+	_, b, ok := getUnknownOption(opts, num)
+	return string(b), ok && b != nil
+}
+
+// getUnknownOption returns the last value of option (provided by field number) from unknown fields of options.
+// It returns varint value or bytes value depending on wire type.
+func getUnknownOption(opts proto.Message, num protowire.Number) (uint64, []byte, bool) {
+	if opts == nil {
+		return 0, nil, false
+	}
+	m := proto.MessageReflect(opts)
+	if !m.IsValid() {
+		return 0, nil, false
+	}
+
+	var (
+		v     uint64
+		b     []byte
+		found bool
+	)
+	data := m.GetUnknown()
+	for len(data) > 0 {
+		n, t, l := protowire.ConsumeTag(data)
+		if l < 0 {
+			return 0, nil, false
+		}
+		data = data[l:]
+
+		var fv uint64
+		var fb []byte
+		switch t {
+		case protowire.VarintType:
+			fv, l = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			fb, l = protowire.ConsumeBytes(data)
+			if fb == nil {
+				fb = []byte{}
+			}
+		default:
+			l = protowire.ConsumeFieldValue(n, t, data)
+		}
+		if l < 0 {
+			return 0, nil, false
+		}
+		data = data[l:]
+
+		if n == num {
+			v, b, found = fv, fb, true
+		}
+	}
+
+	return v, b, found
+}
+
+// gogoCamelCase returns CamelCased name the same way as protoc-gen-gogo does.
+// Following code has been copied from here:
+// https://github.com/gogo/protobuf/blob/master/protoc-gen-gogo/generator/generator.go
+func gogoCamelCase(s string) string {
+	if s == "" {
+		return ""
+	}
+	t := make([]byte, 0, 32)
+	i := 0
+	if s[0] == '_' {
+		// Need a capital letter; drop the '_'.
+		t = append(t, 'X')
+		i++
+	}
+	// Invariant: if the next letter is lower case, it must be converted
+	// to upper case.
+	// That is, we process a word at a time, where words are marked by _ or
+	// upper case letter. Digits are treated as words.
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '_' && i+1 < len(s) && isASCIILower(s[i+1]) {
+			continue // Skip the underscore in s.
+		}
+		if isASCIIDigit(c) {
+			t = append(t, c)
+			continue
+		}
+		// Assume we have a letter now - if not, it's a bogus identifier.
+		// The next word is a sequence of characters that must start upper case.
+		if isASCIILower(c) {
+			c ^= ' ' // Make it a capital letter.
+		}
+		t = append(t, c) // Guaranteed not lower case.
+		// Accept lower case sequence that follows.
+		for i+1 < len(s) && isASCIILower(s[i+1]) {
+			i++
+			t = append(t, s[i])
+		}
+	}
+	return string(t)
+}
+
+// isASCIILower returns true if c is an ASCII lower-case letter
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+// isASCIIDigit returns true if c is an ASCII digit
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -2,114 +2,371 @@ package tagger
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/parser"
 	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
-	"unicode"
+	"sync"
 
 	"github.com/fatih/structtag"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/golang/protobuf/protoc-gen-go/plugin"
+	"google.golang.org/protobuf/compiler/protogen"
 )
 
-// goStruct is map of <field name>->tags.
-type goStruct map[string]*structtag.Tags
+// goField contains data to update Go struct field tags.
+type goField struct {
+	// tags are added to the field tags. Existing tags with the same keys are replaced.
+	tags *structtag.Tags
+
+	// omitempty is map of <tag key>-><true to add, false to remove> 'omitempty' tag option.
+	// It is applied to the field tags after update.
+	omitempty map[string]bool
+
+	// optional is true if the field may be absent in Go struct (e.g. XXX_unrecognized).
+	// Other fields must be found in Go file, otherwise it is an error.
+	optional bool
+
+	// found is true if the field has been found in Go file
+	found bool
+}
+
+// tagKeys contains tag key operations are applied to every field of Go structs in target files
+type tagKeys struct {
+	// rename is map of <old tag key>-><new tag key>
+	rename map[string]string
+
+	// strip contains tag keys to remove
+	strip []string
+}
+
+// empty returns true if there are no tag key operations
+func (k *tagKeys) empty() bool {
+	return len(k.rename) == 0 && len(k.strip) == 0
+}
+
+// validate checks that result of renames doesn't depend on their order,
+// i.e. new tag key is not renamed also (chains and cycles) and is not shared by several old tag keys.
+func (k *tagKeys) validate() error {
+	froms := make([]string, 0, len(k.rename))
+	for from := range k.rename {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	targets := map[string]string{}
+	for _, from := range froms {
+		to := k.rename[from]
+		if _, ok := k.rename[to]; ok {
+			return fmt.Errorf("tag key '%s' is renamed to '%s' which is renamed also", from, to)
+		}
+		if other, ok := targets[to]; ok {
+			return fmt.Errorf("tag keys '%s' and '%s' are renamed to the same tag key '%s'", other, from, to)
+		}
+		targets[to] = from
+	}
+
+	return nil
+}
+
+// apply renames and removes tag keys. Renames are validated, so they don't depend on each other.
+// If tags contain both old and new tag keys the old one is removed.
+func (k *tagKeys) apply(tags *structtag.Tags) {
+	for from, to := range k.rename {
+		t, err := tags.Get(from)
+		if err != nil {
+			continue
+		}
+		if _, err = tags.Get(to); err == nil {
+			tags.Delete(from)
+			continue
+		}
+		t.Key = to
+	}
+
+	if len(k.strip) > 0 {
+		tags.Delete(k.strip...)
+	}
+}
+
+// cloneTag returns deep copy of tag
+func cloneTag(t *structtag.Tag) *structtag.Tag {
+	return &structtag.Tag{Key: t.Key, Name: t.Name, Options: append([]string(nil), t.Options...)}
+}
+
+// goStruct is map of <field name>->field.
+type goStruct map[string]*goField
 
 // goFile is map of <struct name>->struct.
 type goFile struct {
 	structs map[string]goStruct
+
+	// optional is true if the file may be absent (e.g. target file of other Go plugin)
+	optional bool
 }
 
-// toGolangStructName return Go struct name based on proto message name and its parents.
-// Example of proto:
-// message Data1 {
-//	messafe Data2 {
-//	 ... fields
-//	}
-// }
-// So Go struct name of Data2 is Data1Data2.
-func (p *plugin) toGolangStructName(parents []string, name string) string {
-	names := make([]string, len(parents), len(parents)+1)
-	copy(names, parents)
-	names = append(names, name)
+// goNames contains Go names are generated by protoc-gen-go for proto messages, fields and oneofs.
+// Names are resolved by protogen package, so they follow protoc-gen-go naming rules exactly
+// (CamelCase with digits after underscores, suffixes for conflicts with generated methods,
+// oneof wrapper conflicts with nested types, etc.).
+type goNames struct {
+	// structs is map of <full proto message name>-><Go struct name>
+	structs map[string]string
 
-	var n string
-	for _, v := range names {
-		var uppercased bool
-		r := []rune(v)
-		if unicode.IsLower(r[0]) {
-			uppercased = true
-			r[0] = unicode.ToUpper(r[0])
+	// fields is map of <full proto field (oneof) name>-><Go struct field name>
+	fields map[string]string
+
+	// wrappers is map of <full proto oneof field name>-><Go oneof wrapper struct name>
+	wrappers map[string]string
+}
+
+// newGoNames resolves Go names for proto messages, fields and oneofs of proto files.
+// Go names don't depend on Go import paths, but protogen requires valid ones
+// (with '.' or '/' character), so every file without valid import path in 'go_package' option
+// (e.g. empty or legacy package name only "test" or "test;test") gets synthetic one.
+func newGoNames(files []*descriptor.FileDescriptorProto) (*goNames, error) {
+	var params []string
+	for _, f := range files {
+		importPath := f.GetOptions().GetGoPackage()
+		if i := strings.Index(importPath, ";"); i >= 0 {
+			importPath = importPath[:i]
 		}
-		v = string(r)
-		if len(n) > 0 && !uppercased {
-			n += "_"
+		if !strings.ContainsAny(importPath, "./") {
+			params = append(params, "M"+f.GetName()+"=gotagger/"+strings.TrimSuffix(f.GetName(), filepath.Ext(f.GetName())))
 		}
+	}
 
-		n += v
+	req := &plugin_go.CodeGeneratorRequest{ProtoFile: files}
+	if len(params) > 0 {
+		req.Parameter = proto.String(strings.Join(params, ","))
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		return nil, err
 	}
 
-	return n
-}
+	names := &goNames{
+		structs:  map[string]string{},
+		fields:   map[string]string{},
+		wrappers: map[string]string{},
+	}
+	for _, f := range gen.Files {
+		names.addMessages(f.Messages)
+	}
 
-// toGolangFieldName return Go field name based on proto message field name.
-// Example of proto:
-// message Data1 {
-//	string __val2_value = 1;
-// }
-// So Go field name of __val2_value is XVal2Value.
-func (p *plugin) toGolangFieldName(name string) string {
-	r := []rune(name)
-	x := r[0] == '_'
-	ss := strings.Split(name, "_")
+	return names, nil
+}
 
-	var n string
-	if x {
-		n += "X"
-	}
-	for _, s := range ss {
-		if len(s) > 0 {
-			r := []rune(s)
-			r[0] = unicode.ToUpper(r[0])
-			n += string(r)
+// addMessages stores Go names of proto messages (including nested ones), their fields and oneofs
+func (n *goNames) addMessages(messages []*protogen.Message) {
+	for _, m := range messages {
+		n.structs[string(m.Desc.FullName())] = m.GoIdent.GoName
+		for _, f := range m.Fields {
+			n.fields[string(f.Desc.FullName())] = f.GoName
+			if f.Oneof != nil && !f.Desc.HasOptionalKeyword() {
+				n.wrappers[string(f.Desc.FullName())] = f.GoIdent.GoName
+			}
 		}
+		for _, o := range m.Oneofs {
+			n.fields[string(o.Desc.FullName())] = o.GoName
+		}
+		n.addMessages(m.Messages)
 	}
+}
 
-	return n
+// structName returns Go struct name of proto message (provided by full name)
+func (n *goNames) structName(message string) (string, error) {
+	if s, ok := n.structs[message]; ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("failed to resolve Go struct name of message '%s'", message)
+}
+
+// fieldName returns Go struct field name of proto field or oneof (provided by full name)
+func (n *goNames) fieldName(field string) (string, error) {
+	if s, ok := n.fields[field]; ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("failed to resolve Go field name of '%s'", field)
 }
 
-// modifyTargetFiles updates target Go files one by one to insert field tags.
-// It appends updated Go files to the plugin.response.File slice.
+// modifyTargetFiles updates target Go files in parallel to insert field tags.
+// Number of workers is limited by 'jobs' parameter (GOMAXPROCS by default).
+// Go files are generated by wrapped plugin are updated in memory (see 'wrap' parameter).
+// Other Go files are read from plugin file system (output path folder by default)
+// and appended to the plugin.response.File slice in sorted order of file names.
 func (p *plugin) modifyTargetFiles() error {
-	fset := token.NewFileSet()
+	fsys := p.fsys
+	if fsys == nil {
+		root := p.outputPath
+		if len(root) == 0 {
+			root = "."
+		}
+		fsys = os.DirFS(root)
+	}
 
-	for name, file := range p.targetFiles {
-		path := p.outputPath + "/" + name
+	names := make([]string, 0, len(p.targetFiles))
+	for name := range p.targetFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
-		if err != nil {
-			return fmt.Errorf("failed parse Go file '%s': %s", path, err.Error())
+	jobs := p.jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	if jobs > len(names) {
+		jobs = len(names)
+	}
+
+	files := make([]*plugin_go.CodeGeneratorResponse_File, len(names))
+	errs := make([]error, len(names))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				files[i], errs[i] = p.modifyTargetFile(fsys, names[i])
+			}
+		}()
+	}
+	for i := range names {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	for i := range names {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if files[i] != nil {
+			p.response.File = append(p.response.File, files[i])
 		}
+	}
+
+	return nil
+}
 
-		if err = updateTags(f, file.structs); err != nil {
-			return fmt.Errorf("failed to update tags in Go file '%s': %s", path, err.Error())
+// modifyTargetFile updates target Go file (provided by name) to insert field tags.
+// It returns updated Go file to add to response or nil if Go file is generated by wrapped plugin
+// (it is updated in memory), optional Go file is absent or Go file is not changed (see 'only_changed' parameter).
+// Every Go file is parsed with its own file set, so target files may be updated concurrently.
+func (p *plugin) modifyTargetFile(fsys fs.FS, name string) (*plugin_go.CodeGeneratorResponse_File, error) {
+	file := p.targetFiles[name]
+
+	f, err := p.findWrappedFile(name, file.optional)
+	if err != nil {
+		return nil, err
+	}
+	if f != nil {
+		content, err := modifyGoFile(token.NewFileSet(), f.GetName(), []byte(f.GetContent()), file, &p.keys, p.format)
+		if err != nil {
+			return nil, err
 		}
+		f.Content = &content
+		return nil, nil
+	}
+	// Go files of wrapped plugin are never read from file system
+	if len(p.wrap) > 0 {
+		return nil, nil
+	}
 
-		var buf bytes.Buffer
-		if err = format.Node(&buf, fset, f); err != nil {
-			return fmt.Errorf("failed to store updated Go file '%s': %s", path, err.Error())
+	// invalid path of optional Go file is reported by readGoFile func
+	if file.optional && fs.ValidPath(name) {
+		if _, err := fs.Stat(fsys, name); errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
 		}
+	}
 
-		content := buf.String()
-		p.response.File = append(p.response.File, &plugin_go.CodeGeneratorResponse_File{
-			Name:    &name,
-			Content: &content,
-		})
+	src, err := readGoFile(fsys, name)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	content, err := modifyGoFile(token.NewFileSet(), name, src, file, &p.keys, p.format)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.onlyChanged && content == string(src) {
+		return nil, nil
+	}
+
+	return &plugin_go.CodeGeneratorResponse_File{
+		Name:    &name,
+		Content: &content,
+	}, nil
+}
+
+// readGoFile reads Go file (provided by name) from file system.
+// Name must be relative to the file system root: rooted names and '..' elements are rejected.
+func readGoFile(fsys fs.FS, name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("invalid Go file path '%s': must be relative to output root without '..' elements", name)
+	}
+
+	src, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Go file '%s': %s", name, err.Error())
+	}
+
+	return src, nil
+}
+
+// modifyGoFile updates field tags of Go file (provided by path and source) and returns updated Go file.
+// New tag literals are spliced into the original source, so other bytes of Go file are left untouched.
+// Updated Go file is formatted by gofmt rules if gofmt is true (see 'format' parameter).
+func modifyGoFile(fset *token.FileSet, path string, src []byte, file goFile, keys *tagKeys, gofmt bool) (string, error) {
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed parse Go file '%s': %s", path, err.Error())
+	}
+
+	edits, err := updateTags(fset, f, file.structs, keys)
+	if err != nil {
+		return "", fmt.Errorf("failed to update tags in Go file '%s': %s", path, err.Error())
+	}
+
+	content := applyEdits(src, edits)
+	if !gofmt {
+		return string(content), nil
+	}
+
+	formatted, err := format.Source(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to format updated Go file '%s': %s", path, err.Error())
+	}
+
+	return string(formatted), nil
+}
+
+// applyEdits applies non-overlapping text edits to source and returns updated source
+func applyEdits(src []byte, edits []textEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(src)))
+	last := 0
+	for _, e := range edits {
+		buf.Write(src[last:e.start])
+		buf.WriteString(e.text)
+		last = e.end
+	}
+	buf.Write(src[last:])
+
+	return buf.Bytes()
 }
 
 // The following code has been got from here:
@@ -117,8 +374,10 @@ func (p *plugin) modifyTargetFiles() error {
 
 // updateTags updates the existing tags with the map passed and modifies existing tags if any of the keys are matched.
 // First key to the tags argument is the name of the struct, the second key corresponds to field names.
-func updateTags(n ast.Node, tags map[string]goStruct) error {
-	r := retag{}
+// Tag key operations (provided by keys) are applied to every field of every struct.
+// It returns text edits of Go source (provided by fset) to replace, add or remove tag literals.
+func updateTags(fset *token.FileSet, n ast.Node, tags map[string]goStruct, keys *tagKeys) ([]textEdit, error) {
+	r := &retag{fset: fset, keys: keys}
 	f := func(n ast.Node) ast.Visitor {
 		if r.err != nil {
 			return nil
@@ -133,8 +392,20 @@ func updateTags(n ast.Node, tags map[string]goStruct) error {
 	}
 
 	ast.Walk(structVisitor{f}, n)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// make sure Go names are resolved properly and every field is tagged
+	for sn, s := range tags {
+		for fn, field := range s {
+			if !field.found && !field.optional {
+				return nil, fmt.Errorf("field '%s' of struct '%s' is not found", fn, sn)
+			}
+		}
+	}
 
-	return r.err
+	return r.edits, nil
 }
 
 type structVisitor struct {
@@ -152,44 +423,144 @@ func (v structVisitor) Visit(n ast.Node) ast.Visitor {
 }
 
 type retag struct {
-	err  error
-	tags map[string]*structtag.Tags
+	err   error
+	tags  goStruct
+	keys  *tagKeys
+	fset  *token.FileSet
+	edits []textEdit
 }
 
-func (v retag) Visit(n ast.Node) ast.Visitor {
+func (v *retag) Visit(n ast.Node) ast.Visitor {
 	if v.err != nil {
 		return nil
 	}
 
 	if f, ok := n.(*ast.Field); ok {
-		if len(f.Names) == 0 {
+		name := fieldName(f)
+		if len(name) == 0 {
 			return nil
 		}
-		newTags := v.tags[f.Names[0].String()]
-		if newTags == nil {
-			return nil
+		field := v.tags[name]
+		if field != nil {
+			field.found = true
 		}
-
-		if f.Tag == nil {
-			f.Tag = &ast.BasicLit{
-				Kind: token.STRING,
+		if field == nil {
+			if v.keys.empty() || f.Tag == nil {
+				return nil
 			}
+			// there are no new tags but existing tag keys should be renamed or stripped
+			field = &goField{tags: &structtag.Tags{}}
 		}
 
-		oldTags, err := structtag.Parse(strings.Trim(f.Tag.Value, "`"))
+		value := "``"
+		if f.Tag != nil {
+			value = f.Tag.Value
+		}
+
+		tag, err := strconv.Unquote(value)
+		if err != nil {
+			v.err = fmt.Errorf("failed to unquote tags %s of field '%s': %s", value, name, err.Error())
+			return nil
+		}
+
+		oldTags, err := structtag.Parse(tag)
 		if err != nil {
 			v.err = err
 			return nil
 		}
+		if oldTags == nil {
+			oldTags = &structtag.Tags{}
+		}
 
-		for _, t := range newTags.Tags() {
-			oldTags.Set(t)
+		// tags of the field are shared by Go files are updated in parallel, so they are copied before update
+		for _, t := range field.tags.Tags() {
+			oldTags.Set(cloneTag(t))
 		}
 
-		f.Tag.Value = "`" + oldTags.String() + "`"
+		v.keys.apply(oldTags)
+
+		for k, add := range field.omitempty {
+			t, err := oldTags.Get(k)
+			if err != nil || t.Name == "-" {
+				// there is no tag to update or field is ignored
+				continue
+			}
+			if add {
+				oldTags.AddOptions(k, "omitempty")
+			} else {
+				oldTags.DeleteOptions(k, "omitempty")
+			}
+		}
+
+		var lit string
+		if oldTags.Len() > 0 {
+			lit = tagLiteral(oldTags)
+		}
+		v.edit(f, lit)
 
 		return nil
 	}
 
 	return v
 }
+
+// edit records text edit to replace tag literal of Go struct field (provided by lit, empty to remove tag).
+// New tag literal is added after field type.
+func (v *retag) edit(f *ast.Field, lit string) {
+	offset := func(pos token.Pos) int {
+		return v.fset.Position(pos).Offset
+	}
+
+	switch {
+	case f.Tag == nil && len(lit) > 0:
+		at := offset(f.Type.End())
+		v.edits = append(v.edits, textEdit{start: at, end: at, text: " " + lit})
+	case f.Tag != nil && len(lit) == 0:
+		v.edits = append(v.edits, textEdit{start: offset(f.Type.End()), end: offset(f.Tag.End())})
+	case f.Tag != nil && f.Tag.Value != lit:
+		v.edits = append(v.edits, textEdit{start: offset(f.Tag.Pos()), end: offset(f.Tag.End()), text: lit})
+	}
+}
+
+// fieldName returns name of Go struct field.
+// Embedded field has no name, so it returns name of field type (e.g. 'Data' for '*pkg.Data').
+func fieldName(f *ast.Field) string {
+	if len(f.Names) > 0 {
+		return f.Names[0].String()
+	}
+
+	t := f.Type
+	if s, ok := t.(*ast.StarExpr); ok {
+		t = s.X
+	}
+	switch e := t.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+
+	return ""
+}
+
+// tagsString returns string representation of tags.
+// Unlike structtag.Tags.String func it quotes tag values properly,
+// so tag values may contain double quotes, backslashes, etc.
+func tagsString(tags *structtag.Tags) string {
+	ss := make([]string, 0, tags.Len())
+	for _, t := range tags.Tags() {
+		ss = append(ss, t.Key+":"+strconv.Quote(t.Value()))
+	}
+	return strings.Join(ss, " ")
+}
+
+// tagLiteral returns Go string literal of struct field tags.
+// It returns raw string literal unless tags contain back quotes
+// which can't be presented in raw string literal.
+func tagLiteral(tags *structtag.Tags) string {
+	s := tagsString(tags)
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,680 @@
+package tagger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/structtag"
+)
+
+// parameter is 'key=value' item of plugin parameter
+type parameter struct {
+	key   string
+	value string
+
+	// file is path of parameter file the item is loaded from (empty for plugin parameter)
+	file string
+
+	// line and col are position of the item (line is 1 for plugin parameter)
+	line, col int
+}
+
+// position returns readable position of parameter item (e.g. 'position 12' or 'gotagger.params:3:1')
+func (prm *parameter) position() string {
+	return sourcePosition(prm.file, prm.line, prm.col)
+}
+
+// sourcePosition returns readable position in plugin parameter or parameter file
+func sourcePosition(file string, line, col int) string {
+	if len(file) == 0 {
+		return fmt.Sprintf("position %d", col)
+	}
+	return fmt.Sprintf("%s:%d:%d", file, line, col)
+}
+
+// splitParameter splits parameter value (provided by file and line) to 'key=value' items.
+// Grammar:
+// - items are delimited by ',' outside double quotes (double quotes are kept in values)
+// - '\' escapes the next character (e.g. '\,', '\"', '\\'), so it is added to value as is
+// - key is text before the first unescaped '=' outside double quotes, value may be empty
+// - repeated keys are allowed (see setParameter)
+// - empty items are skipped
+// - '@path' item loads items from parameter file (see loadParameterFile)
+// visited contains parameter files are being loaded to detect cycles.
+func splitParameter(value string, file string, line int, visited map[string]bool) ([]parameter, error) {
+	var (
+		params []parameter
+		item   strings.Builder
+		eq     = -1
+		start  int
+		quote  = -1
+	)
+
+	flush := func() error {
+		text, at, col := item.String(), eq, start+1
+		item.Reset()
+		eq = -1
+
+		key := text
+		if at >= 0 {
+			key = text[:at]
+		}
+		key = strings.TrimSpace(key)
+
+		switch {
+		case at < 0 && len(key) == 0:
+			return nil
+		case at < 0 && strings.HasPrefix(key, "@"):
+			ps, err := loadParameterFile(strings.TrimSpace(key[1:]), file, visited)
+			if err != nil {
+				return fmt.Errorf("failed to load parameter file at %s: %s", sourcePosition(file, line, col), err.Error())
+			}
+			params = append(params, ps...)
+			return nil
+		case at < 0:
+			return fmt.Errorf("failed to parse '%s' parameter at %s: must be in 'key=value' format", text, sourcePosition(file, line, col))
+		case len(key) == 0:
+			return fmt.Errorf("failed to parse '%s' parameter at %s: key is empty", text, sourcePosition(file, line, col))
+		}
+
+		params = append(params, parameter{key: key, value: text[at+1:], file: file, line: line, col: col})
+		return nil
+	}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(value) {
+				return nil, fmt.Errorf("dangling escape character at %s", sourcePosition(file, line, i+1))
+			}
+			i++
+			item.WriteByte(value[i])
+		case c == '"':
+			if quote < 0 {
+				quote = i
+			} else {
+				quote = -1
+			}
+			item.WriteByte(c)
+		case c == ',' && quote < 0:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			start = i + 1
+		case c == '=' && quote < 0 && eq < 0:
+			eq = item.Len()
+			item.WriteByte(c)
+		default:
+			item.WriteByte(c)
+		}
+	}
+
+	if quote >= 0 {
+		return nil, fmt.Errorf("unterminated double quote at %s", sourcePosition(file, line, quote+1))
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// loadParameterFile loads parameter items from file (provided by path).
+// Relative path is resolved relative to the folder of parameter file the path is provided by (from),
+// or relative to the current folder for plugin parameter.
+// Every line of parameter file has the same grammar as plugin parameter (see splitParameter),
+// so line may contain one item the same as buf 'opt' list entry or several comma delimited items.
+// Empty lines and lines are started with '#' are skipped.
+func loadParameterFile(path string, from string, visited map[string]bool) ([]parameter, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("parameter file path is empty")
+	}
+	if len(from) > 0 && !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+
+	if visited[path] {
+		return nil, fmt.Errorf("parameter file '%s' is loaded recursively", path)
+	}
+	visited[path] = true
+	defer delete(visited, path)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parameter file '%s': %s", path, err.Error())
+	}
+
+	var params []parameter
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if l := strings.TrimSpace(line); len(l) == 0 || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		ps, err := splitParameter(line, path, i+1, visited)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, ps...)
+	}
+
+	return params, nil
+}
+
+// Types of parameter values (see ParameterInfo)
+const (
+	// ParameterString is string value
+	ParameterString = "string"
+	// ParameterBool is boolean value (true or false)
+	ParameterBool = "bool"
+	// ParameterInt is integer value
+	ParameterInt = "int"
+	// ParameterList is comma delimited list value (e.g. "bson,graphql")
+	ParameterList = "list"
+	// ParameterTags is struct tags value (e.g. bson+"-")
+	ParameterTags = "tags"
+)
+
+// ParameterInfo describes plugin parameter.
+// It is used to build help and to validate plugin configurations (e.g. buf.gen.yaml) by build tools.
+type ParameterInfo struct {
+	// Name is parameter key (e.g. original_field_names)
+	Name string `json:"name"`
+
+	// Type is type of parameter value (see ParameterString, ParameterBool, etc.)
+	Type string `json:"type"`
+
+	// Default is default value or empty if parameter is not set by default
+	Default string `json:"default,omitempty"`
+
+	// Description is short description of parameter
+	Description string `json:"description"`
+
+	// Allowed contains allowed values or it is empty if any value of parameter type is allowed
+	Allowed []string `json:"allowed,omitempty"`
+
+	// Repeated is true if values of repeated parameter accumulate.
+	// Values of other repeated parameters are overwritten by the last value.
+	Repeated bool `json:"repeated"`
+
+	// Example is example of parameter item
+	Example string `json:"example,omitempty"`
+}
+
+// parameterDef is definition of plugin parameter
+type parameterDef struct {
+	ParameterInfo
+
+	// set sets plugin setting by parameter value
+	set func(p *plugin, value string) error
+}
+
+// parameterDefs contains definitions of plugin parameters.
+// Plugin parameter is parsed, validated and described by its definition.
+var parameterDefs = []*parameterDef{
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "xxx",
+			Type:        ParameterTags,
+			Description: "tags for XXX_NoUnkeyedLiteral, XXX_unrecognized, XXX_sizecache struct fields ('+' may be used instead of ':')",
+			Repeated:    true,
+			Example:     `xxx=bson+"-"`,
+		},
+		set: func(p *plugin, value string) error {
+			// '+' is used instead of ':' (see splitItem func), so it is replaced by ':' after parsing.
+			// Value may be quoted (e.g. xxx="bson+\"-\""), so one pair of surrounding quotes is removed.
+			if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+				value = value[1 : len(value)-1]
+			}
+			tags, err := structtag.Parse(strings.Replace(value, `+"`, `:"`, -1))
+			if err != nil {
+				return fmt.Errorf("failed to parse XXX tags '%s': %s", value, err.Error())
+			}
+			if p.xxxTags == nil {
+				p.xxxTags = &structtag.Tags{}
+			}
+			if tags != nil {
+				for _, t := range tags.Tags() {
+					p.xxxTags.Set(t)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "original_field_names",
+			Type:        ParameterList,
+			Description: "tag keys where field names should be equal to proto message field names",
+			Repeated:    true,
+			Example:     `original_field_names="bson,graphql"`,
+		},
+		set: func(p *plugin, value string) error {
+			p.originalFieldNames = append(p.originalFieldNames, parseList(value)...)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "comment_tags",
+			Type:        ParameterList,
+			Description: "tag keys where tag values should be equal to proto field comments",
+			Repeated:    true,
+			Example:     `comment_tags="doc,description"`,
+		},
+		set: func(p *plugin, value string) error {
+			p.commentTags = append(p.commentTags, parseList(value)...)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "omitempty",
+			Type:        ParameterList,
+			Description: "tag keys with policies (always, presence, never) to add or remove 'omitempty' tag option",
+			Repeated:    true,
+			Example:     `omitempty="bson,yaml+presence,db+never"`,
+		},
+		set: func(p *plugin, value string) error {
+			for _, item := range parseList(value) {
+				ss := splitItem(item)
+				policy := omitemptyAlways
+				if len(ss) == 0 || len(ss) > 2 {
+					return fmt.Errorf("invalid omitempty policy '%s': must be in 'key:policy' format", item)
+				}
+				if len(ss) == 2 {
+					var ok bool
+					if policy, ok = omitemptyPolicies[strings.ToLower(ss[1])]; !ok {
+						return fmt.Errorf("unknown omitempty policy '%s' for key '%s'", ss[1], ss[0])
+					}
+				}
+				p.omitempty[ss[0]] = policy
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "number_tags",
+			Type:        ParameterList,
+			Description: "tag keys with options where tag names should be equal to proto field numbers",
+			Repeated:    true,
+			Example:     `number_tags="cbor+keyasint+omitempty,msgpack"`,
+		},
+		set: func(p *plugin, value string) error {
+			for _, item := range parseList(value) {
+				ss := splitItem(item)
+				if len(ss) == 0 {
+					return fmt.Errorf("invalid number tag '%s': must be in 'key:option' format", item)
+				}
+				t := &structtag.Tag{Key: ss[0]}
+				if len(ss) > 1 {
+					t.Options = ss[1:]
+				}
+				p.numberTags = append(p.numberTags, t)
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "hide",
+			Type:        ParameterList,
+			Description: "rules (field URI pattern and tag keys) to hide fields from serialization types",
+			Repeated:    true,
+			Example:     `hide="*.password+json+bson,test.Data.internal+json"`,
+		},
+		set: func(p *plugin, value string) error {
+			for _, item := range parseList(value) {
+				ss := splitItem(item)
+				if len(ss) < 2 {
+					return fmt.Errorf("invalid hide rule '%s': must be in 'pattern:key' format", item)
+				}
+				if _, err := path.Match(ss[0], ""); err != nil {
+					return fmt.Errorf("invalid hide rule pattern '%s': %s", ss[0], err.Error())
+				}
+				p.hideRules = append(p.hideRules, hideRule{pattern: ss[0], keys: ss[1:]})
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "skip",
+			Type:        ParameterList,
+			Description: "message patterns to skip automatically derived tags for",
+			Repeated:    true,
+			Example:     `skip="test.Internal*,test.Data.*"`,
+		},
+		set: func(p *plugin, value string) error {
+			for _, pattern := range parseList(value) {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("invalid skip pattern '%s': %s", pattern, err.Error())
+				}
+				p.skipPatterns = append(p.skipPatterns, pattern)
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "profile",
+			Type:        ParameterList,
+			Description: "selected profiles of structured tags and tag sets",
+			Repeated:    true,
+			Example:     "profile=storage",
+		},
+		set: func(p *plugin, value string) error {
+			p.profiles = append(p.profiles, parseList(value)...)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "rename_keys",
+			Type:        ParameterList,
+			Description: "tag keys (old and new) to rename in every field of generated Go structs",
+			Repeated:    true,
+			Example:     "rename_keys=mgo+bson",
+		},
+		set: func(p *plugin, value string) error {
+			for _, item := range parseList(value) {
+				ss := splitItem(item)
+				if len(ss) != 2 {
+					return fmt.Errorf("invalid rename key '%s': must be in 'old:new' format", item)
+				}
+				if to, ok := p.keys.rename[ss[0]]; ok && to != ss[1] {
+					return fmt.Errorf("tag key '%s' is renamed to both '%s' and '%s'", ss[0], to, ss[1])
+				}
+				p.keys.rename[ss[0]] = ss[1]
+			}
+			return p.keys.validate()
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "strip_keys",
+			Type:        ParameterList,
+			Description: "tag keys to remove from every field of generated Go structs",
+			Repeated:    true,
+			Example:     "strip_keys=graphql",
+		},
+		set: func(p *plugin, value string) error {
+			p.keys.strip = append(p.keys.strip, parseList(value)...)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "json",
+			Type:        ParameterString,
+			Description: "json tags rewrite mode",
+			Allowed:     []string{"protojson"},
+			Example:     "json=protojson",
+		},
+		set: func(p *plugin, value string) error {
+			p.protojson = true
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "json_emit_defaults",
+			Type:        ParameterBool,
+			Default:     "false",
+			Description: "drop 'omitempty' option from rewritten json tags",
+			Example:     "json_emit_defaults=true",
+		},
+		set: func(p *plugin, value string) error {
+			return setBool(&p.jsonEmitDefaults, value)
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "targets",
+			Type:        ParameterList,
+			Description: "target files (Go file name suffix and Go struct name template) with Go structs are generated by other Go plugins",
+			Repeated:    true,
+			Example:     `targets=".pb.dto.go+{{.GoName}}DTO,.pb.vt.go"`,
+		},
+		set: func(p *plugin, value string) error {
+			for _, item := range parseList(value) {
+				ss := splitItem(item)
+				if len(ss) == 0 || len(ss) > 2 {
+					return fmt.Errorf("invalid target '%s': must be in 'suffix:template' format", item)
+				}
+				if ss[0] == goFileSuffix {
+					return fmt.Errorf("invalid target '%s': '%s' files are always tagged", item, goFileSuffix)
+				}
+				var name string
+				if len(ss) == 2 {
+					name = ss[1]
+				}
+				t, err := newTarget(ss[0], name)
+				if err != nil {
+					return fmt.Errorf("invalid target '%s': %s", item, err.Error())
+				}
+				p.targets = append(p.targets, t)
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "wrap",
+			Type:        ParameterString,
+			Description: "plugin to run and tag its Go files in memory",
+			Example:     "wrap=protoc-gen-go",
+		},
+		set: func(p *plugin, value string) error {
+			p.wrap = value
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "wrap_param",
+			Type:        ParameterString,
+			Description: "parameter to pass through to the wrapped plugin",
+			Example:     `wrap_param="paths=source_relative"`,
+		},
+		set: func(p *plugin, value string) error {
+			p.wrapParam = strings.Trim(value, `"`)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "generator",
+			Type:        ParameterString,
+			Default:     generatorGolang,
+			Description: "Go code generator is used to generate Go files",
+			Allowed:     []string{generatorGolang, generatorGogo},
+			Example:     "generator=gogo",
+		},
+		set: func(p *plugin, value string) error {
+			p.generator = strings.ToLower(value)
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "format",
+			Type:        ParameterBool,
+			Default:     "false",
+			Description: "format updated Go files by gofmt rules",
+			Example:     "format=true",
+		},
+		set: func(p *plugin, value string) error {
+			return setBool(&p.format, value)
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "jobs",
+			Type:        ParameterInt,
+			Description: "maximum number of target Go files are updated in parallel (GOMAXPROCS by default)",
+			Example:     "jobs=4",
+		},
+		set: func(p *plugin, value string) error {
+			var err error
+			if p.jobs, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("failed to parse jobs value '%s': %s", value, err.Error())
+			}
+			if p.jobs < 1 {
+				return fmt.Errorf("invalid jobs value '%s': must be positive number", value)
+			}
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "only_changed",
+			Type:        ParameterBool,
+			Default:     "false",
+			Description: "drop Go files are not changed by plugin from response",
+			Example:     "only_changed=true",
+		},
+		set: func(p *plugin, value string) error {
+			return setBool(&p.onlyChanged, value)
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "dump_request",
+			Type:        ParameterString,
+			Description: "file path to save raw request to (see 'replay' command)",
+			Example:     "dump_request=./stdin.bin",
+		},
+		set: func(p *plugin, value string) error {
+			p.dumpRequestPath = value
+			return nil
+		},
+	},
+	{
+		ParameterInfo: ParameterInfo{
+			Name:        "output_path",
+			Type:        ParameterString,
+			Default:     ".",
+			Description: "folder path where generated Go files are located",
+			Example:     "output_path=./test",
+		},
+		set: func(p *plugin, value string) error {
+			p.outputPath = value
+			return nil
+		},
+	},
+}
+
+// Parameters returns descriptions of plugin parameters
+func Parameters() []ParameterInfo {
+	infos := make([]ParameterInfo, 0, len(parameterDefs))
+	for _, d := range parameterDefs {
+		info := d.ParameterInfo
+		info.Allowed = append([]string(nil), d.Allowed...)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// setParameter sets plugin setting by parameter key and value according to parameter definition.
+// Repeated list parameters accumulate values, other repeated parameters are overwritten by the last value.
+func (p *plugin) setParameter(key string, value string) error {
+	key = strings.ToLower(key)
+
+	var def *parameterDef
+	for _, d := range parameterDefs {
+		if d.Name == key {
+			def = d
+			break
+		}
+	}
+	if def == nil {
+		if s := suggestParameter(key); len(s) > 0 {
+			return fmt.Errorf("unknown parameter, did you mean '%s'?", s)
+		}
+		return fmt.Errorf("unknown parameter")
+	}
+
+	if len(def.Allowed) > 0 {
+		allowed := false
+		for _, a := range def.Allowed {
+			allowed = allowed || strings.EqualFold(a, value)
+		}
+		if !allowed {
+			return fmt.Errorf("unsupported value '%s', must be one of: %s", value, strings.Join(def.Allowed, ", "))
+		}
+	}
+
+	return def.set(p, value)
+}
+
+// setBool parses boolean parameter value and stores it to plugin setting (provided by pointer)
+func setBool(setting *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("failed to parse boolean value '%s': %s", value, err.Error())
+	}
+	*setting = b
+	return nil
+}
+
+// suggestParameter returns name of known parameter is the most similar to unknown one (provided by key).
+// It returns empty string if there is no similar parameter.
+func suggestParameter(key string) string {
+	var (
+		best     string
+		bestDist = -1
+	)
+	for _, d := range parameterDefs {
+		dist := editDistance(key, d.Name)
+		// typos are limited to half of parameter name, but prefixes are suggested always (e.g. output -> output_path)
+		if (dist*2 > len(d.Name) || dist > 3) && !strings.HasPrefix(d.Name, key) {
+			continue
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = d.Name, dist
+		}
+	}
+	return best
+}
+
+// editDistance returns distance between strings: number of inserted, deleted, replaced characters
+// and transposed adjacent characters (optimal string alignment distance)
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(minInt(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// minInt returns minimum of two integers
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
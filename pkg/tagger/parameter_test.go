@@ -0,0 +1,299 @@
+package tagger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitParameter(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  []parameter
+		// err is expected part of error
+		err string
+	}{
+		{
+			name:  "quotes and empty values",
+			value: `a=1,b="x,y",c=`,
+			want: []parameter{
+				{key: "a", value: "1", line: 1, col: 1},
+				{key: "b", value: `"x,y"`, line: 1, col: 5},
+				{key: "c", value: "", line: 1, col: 13},
+			},
+		},
+		{
+			name:  "escapes",
+			value: `a=x\,y,b=\"q\",c=\\`,
+			want: []parameter{
+				{key: "a", value: "x,y", line: 1, col: 1},
+				{key: "b", value: `"q"`, line: 1, col: 8},
+				{key: "c", value: `\`, line: 1, col: 16},
+			},
+		},
+		{
+			name:  "value with '='",
+			value: `a=b=c,d="e=f"`,
+			want: []parameter{
+				{key: "a", value: "b=c", line: 1, col: 1},
+				{key: "d", value: `"e=f"`, line: 1, col: 7},
+			},
+		},
+		{
+			name:  "empty items and spaces",
+			value: `,, a =1,`,
+			want:  []parameter{{key: "a", value: "1", line: 1, col: 3}},
+		},
+		{
+			name:  "repeated keys",
+			value: `a=1,a=2`,
+			want: []parameter{
+				{key: "a", value: "1", line: 1, col: 1},
+				{key: "a", value: "2", line: 1, col: 5},
+			},
+		},
+		{name: "empty parameter", value: ``},
+		{name: "unterminated quote", value: `a=1,b="x,y`, err: "unterminated double quote at position 7"},
+		{name: "missing value", value: `a=1,b`, err: "failed to parse 'b' parameter at position 5: must be in 'key=value' format"},
+		{name: "empty key", value: `a=1,=2`, err: "failed to parse '=2' parameter at position 5: key is empty"},
+		{name: "dangling escape", value: `a=1\`, err: "dangling escape character at position 4"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got, err := splitParameter(c.value, "", 1, map[string]bool{})
+			if len(c.err) > 0 {
+				if err == nil || err.Error() != c.err {
+					t.Fatalf("expected error '%s', got: %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("items:\n\tgot:  %+v\n\twant: %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseParameter(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		check func(t *testing.T, p *plugin)
+		err   string
+	}{
+		{
+			name:  "repeated xxx tags are merged",
+			value: `xxx=bson+"-",xxx="graphql+\"-\"",xxx=bson+"ignored"`,
+			check: func(t *testing.T, p *plugin) {
+				if got := p.xxxTags.String(); got != `bson:"ignored" graphql:"-"` {
+					t.Errorf("xxx tags: got `%s`", got)
+				}
+			},
+		},
+		{
+			name:  "repeated lists accumulate",
+			value: `original_field_names=bson,original_field_names="graphql,db"`,
+			check: func(t *testing.T, p *plugin) {
+				if got := strings.Join(p.originalFieldNames, ","); got != "bson,graphql,db" {
+					t.Errorf("original field names: got '%s'", got)
+				}
+			},
+		},
+		{
+			name:  "last value wins",
+			value: `generator=gogo,GENERATOR=golang,json=PROTOJSON`,
+			check: func(t *testing.T, p *plugin) {
+				if p.generator != generatorGolang || !p.protojson {
+					t.Errorf("got generator '%s' and protojson %v", p.generator, p.protojson)
+				}
+			},
+		},
+		{
+			name:  "error position",
+			value: `xxx=bson+"-", jobs=0`,
+			err:   "invalid parameter 'jobs' at position 14: invalid jobs value '0': must be positive number",
+		},
+		{
+			name:  "allowed values",
+			value: `generator=gogoproto`,
+			err:   "invalid parameter 'generator' at position 1: unsupported value 'gogoproto', must be one of: golang, gogo",
+		},
+		{
+			name:  "repeated rename keys",
+			value: `rename_keys=mgo+bson,rename_keys="db+sql,mgo+bson"`,
+			check: func(t *testing.T, p *plugin) {
+				if !reflect.DeepEqual(p.keys.rename, map[string]string{"mgo": "bson", "db": "sql"}) {
+					t.Errorf("rename keys: got %v", p.keys.rename)
+				}
+			},
+		},
+		{
+			name:  "rename key chain",
+			value: `rename_keys="graphql+gql,gql+x"`,
+			err:   "invalid parameter 'rename_keys' at position 1: tag key 'graphql' is renamed to 'gql' which is renamed also",
+		},
+		{
+			name:  "rename key cycle",
+			value: `rename_keys="a+b",rename_keys="b+a"`,
+			err:   "invalid parameter 'rename_keys' at position 19: tag key 'a' is renamed to 'b' which is renamed also",
+		},
+		{
+			name:  "rename keys to the same key",
+			value: `rename_keys="mgo+bson,mongo+bson"`,
+			err:   "invalid parameter 'rename_keys' at position 1: tag keys 'mgo' and 'mongo' are renamed to the same tag key 'bson'",
+		},
+		{
+			name:  "rename key twice",
+			value: `rename_keys="mgo+bson,mgo+mongo"`,
+			err:   "invalid parameter 'rename_keys' at position 1: tag key 'mgo' is renamed to both 'bson' and 'mongo'",
+		},
+		{
+			name:  "unknown parameter",
+			value: `a=1`,
+			err:   "invalid parameter 'a' at position 1: unknown parameter",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			p := newPlugin()
+			err := p.parseParameter(c.value)
+			if len(c.err) > 0 {
+				if err == nil || err.Error() != c.err {
+					t.Fatalf("expected error '%s', got: %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			c.check(t, p)
+		})
+	}
+}
+
+func TestParameterFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.params": "# tags of XXX fields\n" +
+			"xxx=bson+\"-\"\n" +
+			"\n" +
+			"original_field_names=bson  \n" +
+			"@sub/more.params\n",
+		// '@' path is relative to the folder of parameter file
+		"sub/more.params": "xxx=graphql+\"-\",@leaf.params\n",
+		"sub/leaf.params": "comment_tags=doc\n",
+		"bad.params":      "xxx=bson+\"-\"\nxxx=graphql+\"-\",=x\n",
+		"cycle/a.params":  "@b.params\n",
+		"cycle/b.params":  "jobs=2\n@a.params\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	p := newPlugin()
+	if err := p.parseParameter("@" + filepath.Join(dir, "main.params") + ",comment_tags=description"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if got := p.xxxTags.String(); got != `bson:"-" graphql:"-"` {
+		t.Errorf("xxx tags: got `%s`", got)
+	}
+	if got := strings.Join(p.originalFieldNames, ","); got != "bson" {
+		t.Errorf("original field names: got '%s'", got)
+	}
+	if got := strings.Join(p.commentTags, ","); got != "doc,description" {
+		t.Errorf("comment tags: got '%s'", got)
+	}
+
+	bad := filepath.Join(dir, "bad.params")
+	err := newPlugin().parseParameter("jobs=1,@" + bad)
+	if want := "failed to load parameter file at position 8: failed to parse '=x' parameter at " + bad + ":2:17: key is empty"; err == nil || err.Error() != want {
+		t.Errorf("expected error '%s', got: %v", want, err)
+	}
+
+	err = newPlugin().parseParameter("@" + filepath.Join(dir, "cycle", "a.params"))
+	if want := "parameter file '" + filepath.Join(dir, "cycle", "a.params") + "' is loaded recursively"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error containing '%s', got: %v", want, err)
+	}
+
+	err = newPlugin().parseParameter("@" + filepath.Join(dir, "missing.params"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read parameter file") {
+		t.Errorf("expected missing parameter file error, got: %v", err)
+	}
+}
+
+func TestSuggestParameter(t *testing.T) {
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{key: "original_fields_names", want: "original_field_names"},
+		// transposed characters
+		{key: "jbos", want: "jobs"},
+		{key: "omitemtpy", want: "omitempty"},
+		{key: "genrator", want: "generator"},
+		{key: "wrap_params", want: "wrap_param"},
+		// prefixes are suggested always
+		{key: "xx", want: "xxx"},
+		{key: "output", want: "output_path"},
+		{key: "number_tag", want: "number_tags"},
+		// distance is limited by 3 and by half of parameter name
+		{key: "comment_tagsxxx", want: "comment_tags"},
+		{key: "comment_tagsxxxx", want: ""},
+		{key: "xyz", want: ""},
+		{key: "foo", want: ""},
+		{key: "abcdefgh", want: ""},
+	}
+
+	for _, c := range cases {
+		if got := suggestParameter(c.key); got != c.want {
+			t.Errorf("suggestion for '%s': got '%s', want '%s'", c.key, got, c.want)
+		}
+	}
+
+	err := newPlugin().parseParameter("genrator=gogo")
+	if want := "invalid parameter 'genrator' at position 1: unknown parameter, did you mean 'generator'?"; err == nil || err.Error() != want {
+		t.Errorf("expected error '%s', got: %v", want, err)
+	}
+}
+
+func TestParameterDefs(t *testing.T) {
+	types := map[string]bool{ParameterString: true, ParameterBool: true, ParameterInt: true, ParameterList: true, ParameterTags: true}
+	names := map[string]bool{}
+
+	for _, d := range parameterDefs {
+		if names[d.Name] {
+			t.Errorf("parameter '%s' is defined twice", d.Name)
+		}
+		names[d.Name] = true
+
+		if !types[d.Type] || len(d.Description) == 0 || d.set == nil {
+			t.Errorf("parameter '%s' has invalid definition: %+v", d.Name, d.ParameterInfo)
+		}
+		if len(d.Example) > 0 && !strings.HasPrefix(d.Example, d.Name+"=") {
+			t.Errorf("example of parameter '%s' is invalid: %s", d.Name, d.Example)
+		}
+		// allowed values are accepted in any case
+		for _, a := range d.Allowed {
+			if err := newPlugin().setParameter(d.Name, strings.ToUpper(a)); err != nil {
+				t.Errorf("allowed value '%s' of parameter '%s' is rejected: %s", a, d.Name, err.Error())
+			}
+		}
+	}
+}
@@ -3,8 +3,9 @@ package tagger
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
-	"regexp"
+	"os"
 	"strings"
 
 	"github.com/fatih/structtag"
@@ -22,17 +23,86 @@ type Plugin interface {
 	Proccess() error
 }
 
+// DumpRequestEnv is environment variable with file path to save raw CodeGeneratorRequest proto message to.
+// Request is saved before parameter parsing. Saved request may be replayed by 'replay' command.
+// Example:
+// GOTAGGER_DUMP_REQUEST=./stdin.bin protoc --proto_path=. -gotagger_out=output_path=./test:./test data.proto
+const DumpRequestEnv = "GOTAGGER_DUMP_REQUEST"
+
+// omitemptyPolicy defines when 'omitempty' tag option is added to the field tag
+type omitemptyPolicy int
+
+const (
+	// omitemptyAlways adds 'omitempty' option for every field
+	omitemptyAlways omitemptyPolicy = iota
+	// omitemptyPresence adds 'omitempty' option for fields with presence and removes it for others
+	omitemptyPresence
+	// omitemptyNever removes 'omitempty' option for every field
+	omitemptyNever
+)
+
+// omitemptyPolicies is map of <policy name>->policy
+var omitemptyPolicies = map[string]omitemptyPolicy{
+	"always":   omitemptyAlways,
+	"presence": omitemptyPresence,
+	"never":    omitemptyNever,
+}
+
+// hideRule is rule to hide fields (oneofs) from serialization types
+type hideRule struct {
+	// pattern is field URI pattern (see path.Match)
+	pattern string
+
+	// keys are tag keys to hide field from
+	keys []string
+}
+
 // NewPlugin returns new object is implementing Plugin interface
 // in - input stream that contains CodeGeneratorRequest serialized protop message
 // out - output stream to store result CodeGeneratorResponse serialized proto message
-func NewPlugin(in io.Reader, out io.Writer) Plugin {
+// opts - plugin options (see WithTagProviders, WithFS funcs)
+func NewPlugin(in io.Reader, out io.Writer, opts ...PluginOption) Plugin {
+	p := newPlugin()
+	p.in = in
+	p.out = out
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// PluginOption configures plugin (see NewPlugin func)
+type PluginOption func(p *plugin)
+
+// WithTagProviders adds tag providers in addition to registered ones (see RegisterTagProvider func)
+func WithTagProviders(providers ...TagProvider) PluginOption {
+	return func(p *plugin) {
+		p.providers = append(p.providers, providers...)
+	}
+}
+
+// WithFS sets file system to read generated Go files from.
+// Root of the file system is output root, so 'output_path' parameter is not used to read Go files.
+func WithFS(fsys fs.FS) PluginOption {
+	return func(p *plugin) {
+		p.fsys = fsys
+	}
+}
+
+// newPlugin returns plugin with default settings
+func newPlugin() *plugin {
 	return &plugin{
-		in:                 in,
 		request:            &plugin_go.CodeGeneratorRequest{},
 		originalFieldNames: []string{},
+		commentTags:        []string{},
+		omitempty:          map[string]omitemptyPolicy{},
+		keys:               tagKeys{rename: map[string]string{}},
+		generator:          generatorGolang,
+		providers:          registeredTagProviders(),
 		targetFiles:        map[string]goFile{},
-		response:           &plugin_go.CodeGeneratorResponse{},
-		out:                out,
+		response: &plugin_go.CodeGeneratorResponse{
+			SupportedFeatures: proto.Uint64(uint64(plugin_go.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)),
+		},
 	}
 }
 
@@ -45,6 +115,9 @@ type plugin struct {
 	// See here for details: https://github.com/golang/protobuf/blob/master/protoc-gen-go/plugin/plugin.proto
 	request *plugin_go.CodeGeneratorRequest
 
+	// requestData is raw CodeGeneratorRequest proto message is read from input stream
+	requestData []byte
+
 	// xxxTags are tags to add to the following fields for every struct:
 	// XXX_NoUnkeyedLiteral
 	// XXX_unrecognized
@@ -60,12 +133,138 @@ type plugin struct {
 	// protoc --proto_path=. -gotagger_out=xxx="bson+\"-\"",original_field_names=\"bson,graphql\",output_path=./test:./test data.proto
 	originalFieldNames []string
 
+	// commentTags contains tag keys (e.g. doc, description, etc.)
+	// where tag value should be equal to proto field leading comment.
+	// Comment whitespaces are normalized. Fields without comments are not tagged.
+	// Example:
+	// protoc --proto_path=. -gotagger_out=comment_tags=\"doc,description\",output_path=./test:./test data.proto
+	commentTags []string
+
+	// omitempty is map of <tag key>-><policy> to add or remove 'omitempty' tag option automatically.
+	// Policy is provided after tag key delimited by ':' or '+' (see splitItem func).
+	// Supported policies:
+	// always - add 'omitempty' option for every field (default)
+	// presence - add 'omitempty' option for fields with presence (proto3 optional, message, oneof) and remove it for others
+	// never - remove 'omitempty' option for every field
+	// Example:
+	// protoc --proto_path=. -gotagger_out=omitempty=\"bson,yaml+presence,db+never\",output_path=./test:./test data.proto
+	omitempty map[string]omitemptyPolicy
+
+	// protojson is true if json tags should be rewritten to match protojson serialization
+	// (json_name field names, 64-bit integers as strings).
+	// Example:
+	// protoc --proto_path=. -gotagger_out=json=protojson,output_path=./test:./test data.proto
+	protojson bool
+
+	// jsonEmitDefaults is true if 'omitempty' option should be dropped from rewritten json tags
+	// so fields with default values are always emitted.
+	// It is used with protojson mode only. Example:
+	// protoc --proto_path=. -gotagger_out=json=protojson,json_emit_defaults=true,output_path=./test:./test data.proto
+	jsonEmitDefaults bool
+
+	// numberTags contains tag keys with options (e.g. cbor with keyasint, msgpack, etc.)
+	// where tag name should be equal to proto field number.
+	// Options are provided after tag key delimited by ':' or '+' (see splitItem func).
+	// Example:
+	// protoc --proto_path=. -gotagger_out=number_tags=\"cbor+keyasint+omitempty,msgpack\",output_path=./test:./test data.proto
+	// It adds the following tags for field number 5: cbor:"5,keyasint,omitempty" msgpack:"5"
+	numberTags []*structtag.Tag
+
+	// hideRules contains rules to hide fields (oneofs) from serialization types (e.g. json, bson, etc.).
+	// It sets '-' tag name for the provided tag keys of fields (oneofs) are matching the rule.
+	// Rule is field URI pattern (see path.Match) and tag keys delimited by ':' or '+'.
+	// Field URI is full proto name of field (e.g. test.Data.val_vvall).
+	// Example:
+	// protoc --proto_path=. -gotagger_out=hide=\"*.password+json+bson,test.Data.internal+json\",output_path=./test:./test data.proto
+	hideRules []hideRule
+
+	// skipPatterns contains full proto message name patterns (see path.Match)
+	// to skip automatically derived tags (e.g. original field names, omitempty policy) for.
+	// Explicit tags are kept. Example:
+	// protoc --proto_path=. -gotagger_out=skip=\"test.Internal*,test.Data.*\",output_path=./test:./test data.proto
+	skipPatterns []string
+
+	// profiles contains selected profiles (e.g. storage).
+	// Structured tags (tagger.field) and tag sets (tagger.tag_set) are labeled by profiles
+	// are active only if one of their profiles is selected. Unlabeled ones are always active.
+	// Example:
+	// protoc --proto_path=. -gotagger_out=profile=storage,output_path=./test:./test data.proto
+	profiles []string
+
+	// keys contains tag key operations (rename and strip) are applied to every field of generated Go structs.
+	// They are applied to existing tags and to the new ones.
+	// Old and new keys are delimited by ':' or '+' (see splitItem func).
+	// Example:
+	// protoc --proto_path=. -gotagger_out=rename_keys=mgo+bson,strip_keys=graphql,output_path=./test:./test data.proto
+	keys tagKeys
+
 	// outputPath is folder path where generated Go files are located.
 	// Example:
 	// protoc --proto_path=. -gotagger_out=xxx="bson+\"-\"",original_field_names=\"bson,graphql\",output_path=./test:./test data.proto
 	outputPath string
 
+	// targets contains target files with Go structs are generated by other Go plugins (e.g. vtproto, custom generators).
+	// Target is Go file name suffix and optional Go struct name template delimited by ':' or '+' (see splitItem func).
+	// Template data is Go struct name of protoc-gen-go (GoName).
+	// Default struct name template is '{{.GoName}}'. Go field names are expected to be equal to protoc-gen-go ones.
+	// Structs of target files get the same field tags as structs of generated Go files (.pb.go are always tagged).
+	// Missing target files, structs and fields are skipped.
+	// Example:
+	// protoc --proto_path=. -gotagger_out=targets=".pb.dto.go+{{.GoName}}DTO,.pb.vt.go",output_path=./test:./test data.proto
+	targets []target
+
+	// wrap is plugin (e.g. protoc-gen-go) to run with the same request before tagging.
+	// Go files of the wrapped plugin are tagged in memory, so they don't have to be on disk (output_path is not used for them).
+	// Plugin response contains all files of the wrapped plugin.
+	// Example:
+	// protoc --proto_path=. -gotagger_out=wrap=protoc-gen-go,wrap_param="paths=source_relative":./test data.proto
+	wrap string
+
+	// wrapParam is parameter to pass through to the wrapped plugin (e.g. paths=source_relative)
+	wrapParam string
+
+	// generator is Go code generator is used to generate Go files (golang or gogo).
+	// Generator defines Go names of structs and fields and XXX fields of Go structs.
+	// gogo generator supports gogoproto customname, embed and goproto_unrecognized (goproto_unkeyed, goproto_sizecache) options.
+	// Example:
+	// protoc --proto_path=. -gotagger_out=generator=gogo,output_path=./test:./test data.proto
+	generator string
+
+	// format is true if updated Go files should be formatted by gofmt rules.
+	// New tag literals are spliced into the original Go source by default, so other bytes are left untouched
+	// (comments and formatting of Go code generators are kept). Example:
+	// protoc --proto_path=. -gotagger_out=format=true,output_path=./test:./test data.proto
+	format bool
+
+	// jobs is maximum number of target Go files are updated in parallel (GOMAXPROCS by default).
+	// Example:
+	// protoc --proto_path=. -gotagger_out=jobs=4,output_path=./test:./test data.proto
+	jobs int
+
+	// onlyChanged is true if Go files are read from file system and not changed by plugin
+	// should be dropped from response, so their modification time is kept and incremental builds stay incremental.
+	// Go files of wrapped plugin are always returned. Example:
+	// protoc --proto_path=. -gotagger_out=only_changed=true,output_path=./test:./test data.proto
+	onlyChanged bool
+
+	// dumpRequestPath is file path to save raw CodeGeneratorRequest proto message to.
+	// Saved request may be replayed by 'replay' command (see DumpRequestEnv also). Example:
+	// protoc --proto_path=. -gotagger_out=dump_request=./stdin.bin,output_path=./test:./test data.proto
+	dumpRequestPath string
+
+	// fsys is file system to read generated Go files from (see WithFS func).
+	// It is output_path folder of real file system by default.
+	fsys fs.FS
+
+	// providers are tag providers to derive tags for fields and oneofs (see TagProvider interface)
+	providers []TagProvider
+
+	// names contains Go names of proto messages, fields and oneofs of source proto files
+	names *goNames
+
 	// targetFiles is map (filename->content) is containing data to update Go files.
+	// Go files are named by base names in output path folder or by full names in response of wrapped plugin
+	// (see targetFileName func).
 	targetFiles map[string]goFile
 
 	// response is CodeGeneratorResponse proto message contains updated Go files
@@ -80,6 +279,8 @@ type plugin struct {
 func (p *plugin) writeErrorResponse(err string, args ...interface{}) error {
 	s := fmt.Sprintf(err, args...)
 	p.response.Error = &s
+	// files of wrapped plugin are not returned on error
+	p.response.File = nil
 	return p.writeResponse()
 }
 
@@ -90,13 +291,22 @@ func (p *plugin) readRequest() error {
 		return fmt.Errorf("failed to read marshaled request from input: %s", err.Error())
 	}
 
+	p.requestData = data
+
 	if err = proto.Unmarshal(data, p.request); err != nil {
 		return fmt.Errorf("failed to unmarshal request from binary data: %s", err.Error())
 	}
 
-	// TODO: enable code bellow if you need to echo input data to stdin.bin file - it is used for debugging
-	// ioutil.WriteFile("./stdin.bin", data, 0)
+	return nil
+}
 
+// dumpRequest saves raw CodeGeneratorRequest proto message to file (provided by path).
+// Saved request may be replayed by 'replay' command.
+// Request contains source proto files, so the file is readable by owner only.
+func (p *plugin) dumpRequest(path string) error {
+	if err := ioutil.WriteFile(path, p.requestData, 0600); err != nil {
+		return fmt.Errorf("failed to dump request to file '%s': %s", path, err.Error())
+	}
 	return nil
 }
 
@@ -116,51 +326,45 @@ func (p *plugin) writeResponse() error {
 }
 
 // parseParameter parse '-gotagger_out' command line option value
-// It contains two comma delimited optional parameters:
-// xxx - contains tags for XXX_NoUnkeyedLiteral, XXX_unrecognized, XXX_sizecache struct fields
-// output_path - folder path where generated Go files are located
+// It contains comma delimited optional parameters (see parameterDefs for the list of supported parameters).
+// Parameters may be loaded from file by '@path' item (see splitParameter for grammar details).
 // Example:
 // protoc --proto_path=. -gotagger_out=xxx="bson+\"-\"",output_path=./test:./test data.proto
-func (p *plugin) parseParameter() error {
-	var q bool
-	params := strings.FieldsFunc(p.request.GetParameter(), func(c rune) bool {
-		if c == '"' {
-			q = !q
-		}
-		return c == ',' && !q
-	})
+// protoc --proto_path=. -gotagger_out=@./gotagger.params:./test data.proto
+func (p *plugin) parseParameter(parameter string) error {
+	params, err := splitParameter(parameter, "", 1, map[string]bool{})
+	if err != nil {
+		return err
+	}
 
-	re := regexp.MustCompile(`^(.+)=(.+)$`)
-	for _, v := range params {
-		m := re.FindStringSubmatch(v)
-		if len(m) != 3 {
-			return fmt.Errorf("failed to parse '%s' parameter: must be in 'key=value' format", v)
-		}
-		switch strings.ToLower(m[1]) {
-		case "xxx":
-			// we can't use ':' character in command parameter
-			// so we use '+' instead and replace it by ':' after parsing
-			var err error
-			if p.xxxTags, err = structtag.Parse(strings.Replace(m[2], `+"`, `:"`, -1)); err != nil {
-				return fmt.Errorf("failed to parse XXX tags '%s': %s", m[2], err.Error())
-			}
-		case "original_field_names":
-			ss := strings.Split(strings.Trim(m[2], `"`), ",")
-			for _, s := range ss {
-				if len(strings.TrimSpace(s)) > 0 {
-					p.originalFieldNames = append(p.originalFieldNames, s)
-				}
-			}
-		case "output_path":
-			p.outputPath = m[2]
-		default:
-			return fmt.Errorf("unknown parameter: %s", m[1])
+	for _, prm := range params {
+		if err = p.setParameter(prm.key, prm.value); err != nil {
+			return fmt.Errorf("invalid parameter '%s' at %s: %s", prm.key, prm.position(), err.Error())
 		}
 	}
 
 	return nil
 }
 
+// parseList parses comma delimited parameter value (e.g. "bson,graphql") to slice of items
+func parseList(value string) []string {
+	var items []string
+	for _, s := range strings.Split(strings.Trim(value, `"`), ",") {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			items = append(items, s)
+		}
+	}
+	return items
+}
+
+// splitItem splits parameter list item (e.g. "bson:presence") to parts.
+// Parts are delimited by ':' or '+' (we can't use ':' character in command parameter).
+func splitItem(item string) []string {
+	return strings.FieldsFunc(item, func(c rune) bool {
+		return c == ':' || c == '+'
+	})
+}
+
 // Proccess is main and single public func of plugin that
 // analyzes provided source proto files and returns updated Go files
 func (p *plugin) Proccess() error {
@@ -168,10 +372,33 @@ func (p *plugin) Proccess() error {
 		return p.writeErrorResponse(err.Error())
 	}
 
-	if err := p.parseParameter(); err != nil {
+	// request is dumped before parameter parsing, so requests with invalid parameter may be captured also
+	if path := os.Getenv(DumpRequestEnv); len(path) > 0 {
+		if err := p.dumpRequest(path); err != nil {
+			return p.writeErrorResponse(err.Error())
+		}
+	}
+
+	if err := p.parseParameter(p.request.GetParameter()); err != nil {
 		return p.writeErrorResponse("failed to parse 'gotagger_out' parameter value: %s", err.Error())
 	}
 
+	if len(p.dumpRequestPath) > 0 {
+		if err := p.dumpRequest(p.dumpRequestPath); err != nil {
+			return p.writeErrorResponse(err.Error())
+		}
+	}
+
+	if len(p.wrap) > 0 {
+		if err := p.runWrappedPlugin(); err != nil {
+			return p.writeErrorResponse(err.Error())
+		}
+	}
+
+	if err := p.resolveNames(); err != nil {
+		return p.writeErrorResponse("failed to resolve Go names of source proto files: %s", err.Error())
+	}
+
 	if err := p.analyzeSourceFiles(); err != nil {
 		return p.writeErrorResponse("failed to analyze source proto files: %s", err.Error())
 	}
@@ -182,3 +409,14 @@ func (p *plugin) Proccess() error {
 
 	return p.writeResponse()
 }
+
+// resolveNames resolves Go names of source proto files according to Go code generator (see 'generator' parameter)
+func (p *plugin) resolveNames() error {
+	var err error
+	if p.generator == generatorGogo {
+		p.names, err = newGogoNames(p.request.GetProtoFile())
+	} else {
+		p.names, err = newGoNames(p.request.GetProtoFile())
+	}
+	return err
+}
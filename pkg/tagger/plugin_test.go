@@ -0,0 +1,864 @@
+package tagger_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fatih/structtag"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+// The following test fixtures are located in testdata folder:
+// - <name>.protoset is descriptor set of source proto files (with imports and source info)
+// - <name>.pb.go is Go file is generated by protoc-gen-go (or protoc-gen-gogo) without tags
+// - golden/<case>/<name>.pb.go.golden is Go file is updated by plugin
+// Fixtures are regenerated by protoc_testdata_proto.cmd, golden files are updated by '-taggertest.update' flag.
+
+// goFixture returns Go source fixture (provided by file name) from testdata folder
+func goFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read Go fixture '%s': %s", name, err.Error())
+	}
+
+	return string(data)
+}
+
+// golden returns golden folder of test case (provided by name)
+func golden(name string) string {
+	return filepath.Join("testdata", "golden", name)
+}
+
+func TestProtojson(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/protojson.protoset")
+	goFiles := map[string]string{"protojson.pb.go": goFixture(t, "protojson.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "json_name and 64-bit integers",
+			Set:       set,
+			Parameter: "json=protojson",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Protojson": {
+					"ValVvall":      `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"valVvall,omitempty"`,
+					"Renamed":       `protobuf:"bytes,2,opt,name=renamed,json=customName,proto3" json:"customName,omitempty"`,
+					"Int64Value":    `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3" json:"int64Value,string,omitempty"`,
+					"Uint64Value":   `protobuf:"varint,4,opt,name=uint64_value,json=uint64Value,proto3" json:"uint64Value,string,omitempty"`,
+					"Sint64Value":   `protobuf:"zigzag64,5,opt,name=sint64_value,json=sint64Value,proto3" json:"sint64Value,string,omitempty"`,
+					"Fixed64Value":  `protobuf:"fixed64,6,opt,name=fixed64_value,json=fixed64Value,proto3" json:"fixed64Value,string,omitempty"`,
+					"Sfixed64Value": `protobuf:"fixed64,7,opt,name=sfixed64_value,json=sfixed64Value,proto3" json:"sfixed64Value,string,omitempty"`,
+					"Int32Value":    `protobuf:"varint,8,opt,name=int32_value,json=int32Value,proto3" json:"int32Value,omitempty"`,
+					// encoding/json doesn't support 'string' option for slices
+					"Int64Values": `protobuf:"varint,9,rep,packed,name=int64_values,json=int64Values,proto3" json:"int64Values,omitempty"`,
+					"NestedValue": `protobuf:"bytes,10,opt,name=nested_value,json=nestedValue,proto3" json:"nestedValue,omitempty"`,
+					"Choice":      `protobuf_oneof:"choice"`,
+				},
+				"Protojson_ChoiceInt64": {
+					"ChoiceInt64": `protobuf:"varint,11,opt,name=choice_int64,json=choiceInt64,proto3,oneof" json:"choiceInt64,string,omitempty"`,
+				},
+				"Protojson_ChoiceText": {
+					"ChoiceText": `protobuf:"bytes,12,opt,name=choice_text,json=text,proto3,oneof" json:"text,omitempty"`,
+				},
+			},
+			Golden: golden("protojson"),
+		},
+		{
+			Name:      "emit defaults",
+			Set:       set,
+			Parameter: "json=protojson,json_emit_defaults=true",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Protojson": {
+					"Renamed":    `protobuf:"bytes,2,opt,name=renamed,json=customName,proto3" json:"customName"`,
+					"Int64Value": `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3" json:"int64Value,string"`,
+				},
+				"Protojson_ChoiceText": {
+					"ChoiceText": `protobuf:"bytes,12,opt,name=choice_text,json=text,proto3,oneof" json:"text"`,
+				},
+			},
+		},
+		{
+			Name:      "unsupported mode",
+			Set:       set,
+			Parameter: "json=jsonpb",
+			Go:        goFiles,
+			WantErr:   "unsupported value 'jsonpb', must be one of: protojson",
+		},
+	})
+}
+
+func TestNumberTags(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{"data.pb.go": goFixture(t, "data.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "number tags",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `number_tags="cbor+keyasint+omitempty,msgpack"`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					"ValVvall": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty" cbor:"1,keyasint,omitempty" msgpack:"1"`,
+					// explicit msgpack tag of 'number' tag set has priority
+					"Int64Value":   `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty" bson:",omitempty" graphql:"int64_value,optional" validate:"required" msgpack:"8" cbor:"8,keyasint,omitempty"`,
+					"Uint64Values": `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values,omitempty" cbor:"9,keyasint,omitempty" msgpack:"9"`,
+				},
+				"Data_A": {
+					"A": `protobuf:"bytes,5,opt,name=a,proto3,oneof" bson:"A" cbor:"5,keyasint,omitempty" msgpack:"5"`,
+				},
+				// automatic tags are skipped by (tagger.skip_auto) option
+				"Data_BJk": {
+					"BJk": `protobuf:"varint,6,opt,name=b_jk,json=bJk,proto3,oneof" bson:"b_Jk"`,
+				},
+				"DataNested": {
+					"XVal2Value": `protobuf:"bytes,1,opt,name=__val2_value,json=Val2Value,proto3" json:"__val2_value,omitempty" graphql:"name21,optional" bson:"name22,omitempty" cbor:"1,keyasint,omitempty" msgpack:"1"`,
+				},
+			},
+			Golden: golden("number_tags"),
+		},
+		{
+			Name:      "invalid number tag",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `number_tags="cbor,+"`,
+			Go:        goFiles,
+			WantErr:   "invalid number tag '+': must be in 'key:option' format",
+		},
+	})
+}
+
+func TestNames(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+
+	files, err := taggertest.Process(&taggertest.Case{
+		Set:      set,
+		Generate: []string{"names.proto"},
+		Go:       map[string]string{"names.pb.go": goFixture(t, "names.pb.go")},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	structs, err := taggertest.Tags(files)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Go fields are mapped to proto fields (oneofs) by protobuf tags are generated by protoc-gen-go,
+	// so the test doesn't depend on Go naming rules
+	got := map[string]string{}
+	for _, fields := range structs {
+		for _, tag := range fields {
+			tags, err := structtag.Parse(tag)
+			if err != nil || tags == nil {
+				continue
+			}
+			db, err := tags.Get("db")
+			if err != nil {
+				continue
+			}
+			if oneof, err := tags.Get("protobuf_oneof"); err == nil {
+				got[oneof.Name] = db.Value()
+			}
+			if pb, err := tags.Get("protobuf"); err == nil {
+				for _, o := range pb.Options {
+					if strings.HasPrefix(o, "name=") {
+						got[strings.TrimPrefix(o, "name=")] = db.Value()
+					}
+				}
+			}
+		}
+	}
+
+	var want []string
+	var walk func(messages []*descriptor.DescriptorProto)
+	walk = func(messages []*descriptor.DescriptorProto) {
+		for _, m := range messages {
+			for _, f := range m.GetField() {
+				want = append(want, f.GetName())
+			}
+			for _, o := range m.GetOneofDecl() {
+				want = append(want, o.GetName())
+			}
+			walk(m.GetNestedType())
+		}
+	}
+	for _, f := range set.GetFile() {
+		if f.GetName() == "names.proto" {
+			walk(f.GetMessageType())
+		}
+	}
+
+	if len(want) == 0 {
+		t.Fatal("names.proto is not found in descriptor set")
+	}
+	// every field (oneof) of names.proto is annotated by db tag is equal to its proto name
+	for _, name := range want {
+		if got[name] != name {
+			t.Errorf("tag of '%s' is not found: got db:\"%s\"", name, got[name])
+		}
+	}
+}
+
+func TestGogo(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/gogo/gogodata.protoset")
+	goFiles := map[string]string{"gogodata.pb.go": goFixture(t, "gogo/gogodata.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "customname, embed and XXX options",
+			Set:       set,
+			Parameter: `generator=gogo,xxx=bson+"-",original_field_names=graphql`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Gogo": {
+					"ValueAll": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" bson:"val" graphql:"val_vvall"`,
+					// embedded field is matched by type name
+					"Base":                 `protobuf:"bytes,2,opt,name=base,proto3,embedded=base" json:"base,omitempty" bson:",inline" graphql:"base"`,
+					"NotNull":              `protobuf:"bytes,3,opt,name=not_null,json=notNull,proto3" json:"not_null" bson:"not_null" graphql:"not_null"`,
+					"Size_":                `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty" bson:"size" graphql:"size"`,
+					"Choice":               `protobuf_oneof:"choice" bson:"choice" graphql:"choice"`,
+					"XXX_NoUnkeyedLiteral": `json:"-" bson:"-"`,
+				},
+				"Gogo_Alpha": {
+					"Alpha": `protobuf:"bytes,5,opt,name=a,proto3,oneof" json:"a,omitempty" bson:"a" graphql:"a"`,
+				},
+				"Base": {
+					"ID":            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" bson:"_id" graphql:"id"`,
+					"XXX_sizecache": `json:"-" bson:"-"`,
+				},
+			},
+			Golden: golden("gogo"),
+		},
+		{
+			Name:      "golang generator",
+			Set:       set,
+			Parameter: "generator=golang",
+			Go:        goFiles,
+			// customname fields are not found by protoc-gen-go naming rules
+			WantErr: "failed to update tags in Go file 'gogodata.pb.go'",
+		},
+	})
+}
+
+func TestTargets(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{
+		"data.pb.go":     goFixture(t, "data.pb.go"),
+		"data.pb.dto.go": goFixture(t, "data.pb.dto.go"),
+	}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "struct name template",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `targets=".pb.dto.go+{{.GoName}}DTO",original_field_names=db`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"DataDTO": {
+					"ValVvall":   `json:"val_vvall" graphql:"name11,optional" bson:"name12,omitempty" db:"val_vvall"`,
+					"Int64Value": `bson:",omitempty" graphql:"int64_value,optional" validate:"required" msgpack:"8" db:"int64_value"`,
+					// fields without proto fields are left untouched
+					"Extra": `json:"extra"`,
+				},
+				"DataNestedDTO": {
+					"XVal2Value": `graphql:"name21,optional" bson:"name22,omitempty" db:"__val2_value"`,
+				},
+			},
+		},
+		{
+			Name:      "missing target file is skipped",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `targets=".pb.vt.go"`,
+			Go:        map[string]string{"data.pb.go": goFixture(t, "data.pb.go")},
+			Want: map[string]map[string]string{
+				"Data": {
+					"ValVvall": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty"`,
+				},
+			},
+		},
+		{
+			Name:      "protoc-gen-go file",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `targets=".pb.go"`,
+			Go:        goFiles,
+			WantErr:   "invalid target '.pb.go': '.pb.go' files are always tagged",
+		},
+	})
+}
+
+func TestFS(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:     "missing Go file",
+			Set:      set,
+			Generate: []string{"data.proto"},
+			Go:       map[string]string{"names.pb.go": goFixture(t, "names.pb.go")},
+			WantErr:  "failed to read Go file 'data.pb.go'",
+		},
+		{
+			Name:      "path traversal",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `targets="/../data.pb.dto.go"`,
+			Go:        map[string]string{"data.pb.go": goFixture(t, "data.pb.go")},
+			WantErr:   "invalid Go file path 'data/../data.pb.dto.go': must be relative to output root without '..' elements",
+		},
+		{
+			Name:     "Go files with the same base name",
+			Set:      withOtherData(set),
+			Generate: []string{"data.proto", "other/data.proto"},
+			Go:       map[string]string{"data.pb.go": goFixture(t, "data.pb.go")},
+			WantErr:  "Go file 'data.pb.go' is generated for other source proto file also",
+		},
+	})
+}
+
+func TestData(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{
+		"data.pb.go":  goFixture(t, "data.pb.go"),
+		"names.pb.go": goFixture(t, "names.pb.go"),
+	}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "explicit and original field name tags",
+			Set:       set,
+			Parameter: `xxx=bson+"-",original_field_names="bson,graphql"`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					// explicit tags have priority
+					"ValVvall": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty"`,
+					"OneOf":    `protobuf_oneof:"one_of" graphql:"withNewTags,optional" bson:"one_of"`,
+					// tag set has priority, empty tag name is filled by original field name
+					"Int64Value":           `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty" bson:"int64_value,omitempty" graphql:"int64_value,optional" validate:"required" msgpack:"8"`,
+					"Uint64Values":         `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values,omitempty" bson:"uint64_values" graphql:"uint64_values"`,
+					"Password":             `protobuf:"bytes,10,opt,name=password,proto3" json:"-" bson:"-" graphql:"password"`,
+					"XXX_NoUnkeyedLiteral": `json:"-" bson:"-"`,
+				},
+				"Names": {
+					"Reset_": `protobuf:"bytes,1,opt,name=reset,proto3" json:"reset,omitempty" db:"reset" bson:"reset" graphql:"reset"`,
+				},
+			},
+			Golden: golden("data"),
+		},
+		{
+			Name:      "comment tags and omitempty policy",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `comment_tags=doc,omitempty="bson,json+never"`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					"ValVvall":     `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall" graphql:"name11,optional" bson:"name12,omitempty" doc:"val_vvall is \"string\" value with ` + "`multiline`" + ` comment."`,
+					"OneOf":        `protobuf_oneof:"one_of" graphql:"withNewTags,optional" doc:"one_of is oneof value."`,
+					"Uint64Values": `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values"`,
+				},
+			},
+		},
+		{
+			Name:      "storage profile",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: "profile=storage",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					"Password": `protobuf:"bytes,10,opt,name=password,proto3" json:"-" db:"password_hash" bson:"-"`,
+				},
+			},
+		},
+		{
+			Name:      "unknown parameter",
+			Set:       set,
+			Parameter: "original_fields_names=bson",
+			Go:        goFiles,
+			WantErr:   "did you mean 'original_field_names'?",
+		},
+	})
+}
+
+func TestFormat(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	// Go source isn't formatted by gofmt rules
+	src := strings.Replace(goFixture(t, "data.pb.go"), "package test", "package   test // not formatted", 1)
+
+	cases := []struct {
+		name      string
+		parameter string
+		// pkg is expected package clause of updated Go file
+		pkg string
+	}{
+		{name: "tag literals only", parameter: "original_field_names=bson", pkg: "package   test // not formatted"},
+		{name: "gofmt", parameter: "original_field_names=bson,format=true", pkg: "package test // not formatted"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			files, err := taggertest.Process(&taggertest.Case{
+				Set:       set,
+				Generate:  []string{"data.proto"},
+				Parameter: c.parameter,
+				Go:        map[string]string{"data.pb.go": src},
+			})
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			got := files["data.pb.go"]
+			if !strings.Contains(got, "\n"+c.pkg+"\n") {
+				t.Errorf("package clause '%s' is not found in updated Go file", c.pkg)
+			}
+			if !strings.Contains(got, `json:"uint64_values,omitempty" bson:"uint64_values"`) {
+				t.Error("tags of 'Uint64Values' field are not updated")
+			}
+		})
+	}
+}
+
+// TestJobs updates Go files in parallel. Go files share tags (e.g. xxx ones) and they are renamed and
+// updated by omitempty policy per Go file, so the test should be run with -race flag also.
+func TestJobs(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	src := goFixture(t, "data.pb.go")
+
+	// target files get the same tags as data.pb.go, so there are many Go files share tags
+	var suffixes []string
+	goFiles := map[string]string{"data.pb.go": src}
+	for i := 0; i < 64; i++ {
+		s := fmt.Sprintf(".pb.%d.go", i)
+		suffixes = append(suffixes, s)
+		goFiles["data"+s] = src
+	}
+
+	files, err := taggertest.Process(&taggertest.Case{
+		Set:      set,
+		Generate: []string{"data.proto"},
+		Parameter: `jobs=8,xxx=bson+"-",original_field_names=bson,omitempty=graphql,rename_keys=bson+mongo,targets="` +
+			strings.Join(suffixes, ",") + `"`,
+		Go: goFiles,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	want := files["data.pb.go"]
+	for _, s := range suffixes {
+		if files["data"+s] != want {
+			t.Errorf("Go file 'data%s' differs from 'data.pb.go'", s)
+		}
+	}
+
+	structs, err := taggertest.Tags(map[string]string{"data.pb.go": want})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for sn, want := range map[string]map[string]string{
+		"Data": {
+			"ValVvall":             `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional,omitempty" mongo:"name12,omitempty"`,
+			"Int64Value":           `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty" mongo:"int64_value,omitempty" graphql:"int64_value,optional,omitempty" validate:"required" msgpack:"8"`,
+			"XXX_NoUnkeyedLiteral": `json:"-" mongo:"-"`,
+		},
+		"DataNested": {
+			"XXX_sizecache": `json:"-" mongo:"-"`,
+		},
+	} {
+		for fn, tags := range want {
+			if got := structs[sn][fn]; got != tags {
+				t.Errorf("tags of field '%s' of struct '%s':\n\tgot:  `%s`\n\twant: `%s`", fn, sn, got, tags)
+			}
+		}
+	}
+}
+
+func TestOnlyChanged(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	parameter := `xxx=bson+"-",original_field_names="bson,graphql"`
+	// names.pb.go is already updated by the same parameter
+	fsys := fstest.MapFS{
+		"data.pb.go":  &fstest.MapFile{Data: []byte(goFixture(t, "data.pb.go"))},
+		"names.pb.go": &fstest.MapFile{Data: []byte(goFixture(t, "golden/data/names.pb.go.golden"))},
+	}
+
+	cases := []struct {
+		name      string
+		parameter string
+		// files are expected Go files of response
+		files []string
+	}{
+		{name: "all files", parameter: parameter, files: []string{"data.pb.go", "names.pb.go"}},
+		{name: "changed files", parameter: parameter + ",only_changed=true", files: []string{"data.pb.go"}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			req, err := proto.Marshal(&plugin_go.CodeGeneratorRequest{
+				FileToGenerate: []string{"data.proto", "names.proto"},
+				Parameter:      proto.String(c.parameter),
+				ProtoFile:      set.GetFile(),
+			})
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			var out bytes.Buffer
+			if err = tagger.NewPlugin(bytes.NewReader(req), &out, tagger.WithFS(fsys)).Proccess(); err != nil {
+				t.Fatal(err.Error())
+			}
+			resp := &plugin_go.CodeGeneratorResponse{}
+			if err = proto.Unmarshal(out.Bytes(), resp); err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(resp.GetError()) > 0 {
+				t.Fatal(resp.GetError())
+			}
+
+			var files []string
+			for _, f := range resp.GetFile() {
+				files = append(files, f.GetName())
+			}
+			if strings.Join(files, ",") != strings.Join(c.files, ",") {
+				t.Errorf("response files:\n\tgot:  %v\n\twant: %v", files, c.files)
+			}
+		})
+	}
+}
+
+func TestDumpRequest(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	path := filepath.Join(t.TempDir(), "stdin.bin")
+
+	req, err := proto.Marshal(&plugin_go.CodeGeneratorRequest{
+		FileToGenerate: []string{"data.proto"},
+		Parameter:      proto.String("dump_request=" + path),
+		ProtoFile:      set.GetFile(),
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	fsys := fstest.MapFS{"data.pb.go": &fstest.MapFile{Data: []byte(goFixture(t, "data.pb.go"))}}
+	var out bytes.Buffer
+	if err = tagger.NewPlugin(bytes.NewReader(req), &out, tagger.WithFS(fsys)).Proccess(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bytes.Equal(data, req) {
+		t.Error("dumped request is not equal to raw request")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("dumped request file mode: got %o, want 600", mode)
+	}
+}
+
+func TestHide(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{"data.pb.go": goFixture(t, "data.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "hide rules",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `hide="*.password+graphql,test.Data.int64_value+json,test.Data.nested.*+json",original_field_names=graphql`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					// (tagger.hide) option and hide rules are merged
+					"Password":   `protobuf:"bytes,10,opt,name=password,proto3" json:"-" graphql:"-" bson:"-"`,
+					"Int64Value": `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"-" bson:",omitempty" graphql:"int64_value,optional" validate:"required" msgpack:"8"`,
+					// rule doesn't match other fields
+					"Uint64Values": `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values,omitempty" graphql:"uint64_values"`,
+				},
+				"DataNested": {
+					"XVal2Value": `protobuf:"bytes,1,opt,name=__val2_value,json=Val2Value,proto3" json:"-" graphql:"name21,optional" bson:"name22,omitempty"`,
+				},
+			},
+		},
+		{
+			Name:      "hidden key with explicit name",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `hide="test.Data.val_vvall+bson"`,
+			Go:        goFiles,
+			WantErr:   "tag key 'bson' is hidden but has explicit name 'name12'",
+		},
+	})
+}
+
+func TestTagKeys(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{"data.pb.go": goFixture(t, "data.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "rename existing and new tag keys",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `rename_keys="json+js,validate+valid"`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					// json tag is generated by protoc-gen-go, validate tag is provided by annotation
+					"Int64Value": `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" js:"int64_value,omitempty" bson:",omitempty" graphql:"int64_value,optional" valid:"required" msgpack:"8"`,
+					// json tag is provided by (tagger.hide) option
+					"Password": `protobuf:"bytes,10,opt,name=password,proto3" js:"-" bson:"-"`,
+				},
+			},
+		},
+		{
+			Name:      "old tag key is removed if new one exists",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `rename_keys=graphql+bson`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					"Int64Value": `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty" bson:",omitempty" validate:"required" msgpack:"8"`,
+				},
+			},
+		},
+		{
+			Name:      "strip existing and new tag keys",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: `strip_keys="json,validate",rename_keys=msgpack+mp`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Data": {
+					"Int64Value": `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" bson:",omitempty" graphql:"int64_value,optional" mp:"8"`,
+				},
+			},
+		},
+	})
+}
+
+// rulesFixture returns descriptor set and Go files of testdata/rules folder.
+// Every rule case is in its own proto file, so files with errors are generated separately.
+func rulesFixture(t *testing.T) (*descriptor.FileDescriptorSet, map[string]string) {
+	t.Helper()
+
+	set := taggertest.LoadDescriptorSet(t, "testdata/rules/rules.protoset")
+	goFiles := map[string]string{}
+	for _, f := range set.GetFile() {
+		name := strings.TrimSuffix(f.GetName(), ".proto") + ".pb.go"
+		if _, err := os.Stat(filepath.Join("testdata", "rules", name)); err == nil {
+			goFiles[name] = goFixture(t, "rules/"+name)
+		}
+	}
+
+	return set, goFiles
+}
+
+func TestStructuredTagsConflict(t *testing.T) {
+	set, goFiles := rulesFixture(t)
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:     "structured tag conflicts with tagger.tags",
+			Set:      set,
+			Generate: []string{"conflict.proto"},
+			Go:       goFiles,
+			WantErr:  "tag key 'bson' has conflicting values 'value' and 'other'",
+		},
+	})
+}
+
+func TestTagSets(t *testing.T) {
+	set, goFiles := rulesFixture(t)
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:     "imported tag sets",
+			Set:      set,
+			Generate: []string{"sets.proto", "importer.proto"},
+			Go:       goFiles,
+			Want: map[string]map[string]string{
+				"Sets": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional"`,
+				},
+				// message_use tag set is applied to every field
+				"Importer": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional"`,
+					"Sets":  `protobuf:"bytes,2,opt,name=sets,proto3" json:"sets,omitempty" graphql:"sets,optional"`,
+				},
+			},
+		},
+		{
+			Name:     "unknown tag set",
+			Set:      set,
+			Generate: []string{"unknown_set.proto"},
+			Go:       goFiles,
+			WantErr:  "failed to get tags for field 'rules.UnknownSet.value': failed to apply tag sets: unknown tag set 'missing'",
+		},
+		{
+			Name:     "template error",
+			Set:      set,
+			Generate: []string{"template_error.proto"},
+			Go:       goFiles,
+			WantErr:  "failed to expand tag 'db' of tag set 'bad'",
+		},
+	})
+}
+
+func TestTagSetProfiles(t *testing.T) {
+	set, goFiles := rulesFixture(t)
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "active profile",
+			Set:       set,
+			Generate:  []string{"sets.proto", "importer.proto"},
+			Parameter: "profile=storage",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Sets": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional" db:"value"`,
+				},
+				"Importer": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional" db:"value"`,
+				},
+			},
+		},
+		{
+			Name:      "inactive profile",
+			Set:       set,
+			Generate:  []string{"sets.proto", "importer.proto"},
+			Parameter: "profile=sdk",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Sets": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional"`,
+				},
+				"Importer": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" graphql:"value,optional"`,
+				},
+			},
+		},
+	})
+}
+
+func TestSkipAuto(t *testing.T) {
+	set, goFiles := rulesFixture(t)
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "skip patterns and message option",
+			Set:       set,
+			Generate:  []string{"skip.proto"},
+			Parameter: `skip="rules.Internal*",original_field_names=graphql,number_tags=msgpack`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Public": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"public_value" graphql:"value" msgpack:"1"`,
+				},
+				// explicit tags are kept
+				"InternalState": {
+					"Value":   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"internal_value"`,
+					"Counter": `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`,
+				},
+				// (tagger.message_skip_auto) skips fields and oneofs of message
+				"Opaque": {
+					"Value":   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"opaque_value"`,
+					"Counter": `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`,
+					"Choice":  `protobuf_oneof:"choice"`,
+				},
+				"Opaque_Text": {
+					"Text": `protobuf:"bytes,3,opt,name=text,proto3,oneof"`,
+				},
+			},
+		},
+		{
+			Name:      "pattern doesn't match",
+			Set:       set,
+			Generate:  []string{"skip.proto"},
+			Parameter: `skip="rules.Internal",original_field_names=graphql`,
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"InternalState": {
+					"Counter": `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty" graphql:"counter"`,
+				},
+			},
+		},
+		{
+			Name:      "invalid pattern",
+			Set:       set,
+			Generate:  []string{"skip.proto"},
+			Parameter: `skip="rules.[Internal"`,
+			Go:        goFiles,
+			WantErr:   "invalid skip pattern 'rules.[Internal': syntax error in pattern",
+		},
+	})
+}
+
+func TestLegacyGoPackage(t *testing.T) {
+	set, goFiles := rulesFixture(t)
+
+	// legacy.proto and its dependency have package name only 'go_package' option
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "package name only go_package",
+			Set:       set,
+			Generate:  []string{"legacy.proto"},
+			Parameter: "original_field_names=graphql",
+			Go:        goFiles,
+			Want: map[string]map[string]string{
+				"Legacy": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"v" graphql:"value"`,
+					"Dep":   `protobuf:"bytes,2,opt,name=dep,proto3" json:"dep,omitempty" graphql:"dep"`,
+				},
+			},
+		},
+	})
+
+	req := &plugin_go.CodeGeneratorRequest{FileToGenerate: []string{"legacy.proto"}, ProtoFile: set.GetFile()}
+	plan, err := tagger.Analyze(req, tagger.Options{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if fp := plan["legacy.pb.go"]; fp == nil || fp.Structs["Legacy"]["Value"] == nil {
+		t.Errorf("expected plan of 'Legacy.Value' field, got: %v", plan)
+	}
+
+	src := strings.Replace(goFiles["legacy.pb.go"], `json:"value,omitempty"`, `json:"value,omitempty" yaml:"v"`, 1)
+	fields, err := tagger.Extract(set, fstest.MapFS{"legacy.pb.go": {Data: []byte(src)}}, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(fields) != 1 || fields[0].Field != "legacy.Legacy.value" || fields[0].Tags != `yaml:"v"` {
+		t.Errorf("expected hand-edited tag of 'legacy.Legacy.value' field only, got: %v", fields)
+	}
+}
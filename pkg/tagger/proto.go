@@ -2,8 +2,10 @@ package tagger
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/structtag"
@@ -13,6 +15,19 @@ import (
 	"github.com/amsokol/protoc-gen-gotagger/proto/tagger"
 )
 
+// Field numbers are used to build location paths in source proto file.
+// See descriptor.SourceCodeInfo_Location for details.
+const (
+	// messageTypePath is FileDescriptorProto.message_type field number
+	messageTypePath = 4
+	// fieldPath is DescriptorProto.field field number
+	fieldPath = 2
+	// nestedTypePath is DescriptorProto.nested_type field number
+	nestedTypePath = 3
+	// oneofDeclPath is DescriptorProto.oneof_decl field number
+	oneofDeclPath = 8
+)
+
 // analyzeSourceFiles scans source proto files one by one (calls plugin.analyzeFile func) to extract field tags
 func (p *plugin) analyzeSourceFiles() error {
 	for _, f := range p.request.GetProtoFile() {
@@ -34,6 +49,73 @@ func (p *plugin) analyzeSourceFiles() error {
 	return nil
 }
 
+// tagExtensions are proto extensions to provide tags for field or oneof
+type tagExtensions struct {
+	// tags is extension contains tags in struct tag format (e.g. (tagger.tags))
+	tags *proto.ExtensionDesc
+
+	// hide is extension contains comma delimited tag keys to hide (e.g. (tagger.hide))
+	hide *proto.ExtensionDesc
+
+	// field is extension contains structured tags (e.g. (tagger.field))
+	field *proto.ExtensionDesc
+
+	// use is extension contains comma delimited tag set names (e.g. (tagger.use))
+	use *proto.ExtensionDesc
+
+	// skipAuto is extension contains flag to skip automatically derived tags (e.g. (tagger.skip_auto))
+	skipAuto *proto.ExtensionDesc
+}
+
+var (
+	// fieldExtensions are proto extensions of field options
+	fieldExtensions = tagExtensions{
+		tags: tagger.E_Tags, hide: tagger.E_Hide, field: tagger.E_Field, use: tagger.E_Use, skipAuto: tagger.E_SkipAuto,
+	}
+
+	// oneofExtensions are proto extensions of oneof options
+	oneofExtensions = tagExtensions{
+		tags: tagger.E_OneofTags, hide: tagger.E_OneofHide, field: tagger.E_OneofField, use: tagger.E_OneofUse,
+		skipAuto: tagger.E_OneofSkipAuto,
+	}
+)
+
+// protoFile contains source proto file data are used to analyze proto messages
+type protoFile struct {
+	// desc is source proto file descriptor
+	desc *descriptor.FileDescriptorProto
+
+	// comments is map of <location path>-><normalized leading comment> (see plugin.getComments func)
+	comments map[string]string
+
+	// tagSets is map of <name>->tag set are available for source proto file (see plugin.getTagSets func)
+	tagSets map[string]*tagSet
+
+	// target contains tags to update Go file is generated for source proto file
+	target goFile
+}
+
+// protoField contains proto field (oneof) data are used to get tags
+type protoField struct {
+	// opts are field (oneof) options
+	opts proto.Message
+
+	// target describes field (oneof) for tag providers (see TagProvider interface)
+	target *TagTarget
+
+	// exts are proto extensions to get tags from field (oneof) options
+	exts tagExtensions
+
+	// uri is full proto field (oneof) name (see plugin.getFieldURI func)
+	uri string
+
+	// uses are tag set names are applied to every field of proto message
+	uses []string
+
+	// data contains field (oneof) data are available in tag set templates
+	data templateData
+}
+
 // analyzeFile scans source proto file (provided by 'f') to extract field tags
 // It proccess each proto message in the file one by one to find field tags.
 // In case on found it stores tags in plugin.targetFiles map to update Go files on the next phases.
@@ -42,131 +124,486 @@ func (p *plugin) analyzeFile(f *descriptor.FileDescriptorProto) error {
 		return fmt.Errorf("unsupported syntax '%s', must be 'proto3'", f.GetSyntax())
 	}
 
-	file := goFile{structs: map[string]goStruct{}}
+	sets, err := p.getTagSets(f)
+	if err != nil {
+		return fmt.Errorf("failed to get tag sets: %s", err.Error())
+	}
+
+	file := &protoFile{
+		desc:     f,
+		comments: p.getComments(f),
+		tagSets:  sets,
+		target:   goFile{structs: map[string]goStruct{}},
+	}
 
-	for _, m := range f.GetMessageType() {
-		if err := p.analyzeMessageType(file, []string{}, m); err != nil {
+	for i, m := range f.GetMessageType() {
+		path := []int32{messageTypePath, int32(i)}
+		if err := p.analyzeMessageType(file, []string{}, path, m); err != nil {
 			return fmt.Errorf("failed to analyze message type '%s': %s", m.GetName(), err.Error())
 		}
 	}
 
-	if len(file.structs) > 0 {
-		n := filepath.Base(f.GetName())
-		n = strings.TrimSuffix(n, filepath.Ext(n))
-		p.targetFiles[n+".pb.go"] = file
+	if len(file.target.structs) > 0 || !p.keys.empty() {
+		name, err := p.targetFileName(f, goFileSuffix)
+		if err != nil {
+			return err
+		}
+		if _, ok := p.targetFiles[name]; ok {
+			return fmt.Errorf("Go file '%s' is generated for other source proto file also", name)
+		}
+		p.targetFiles[name] = file.target
+		if err := p.addTargets(f, file.target); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// getGoFileName returns name of Go file is generated for source proto file.
+// Example: Go file name of 'test/data.proto' is 'data.pb.go'.
+func (p *plugin) getGoFileName(f *descriptor.FileDescriptorProto) string {
+	return p.getGoFileBase(f) + goFileSuffix
+}
+
+// targetFileName returns name of Go file (provided by suffix) to update for source proto file.
+// It is full name of Go file in response of wrapped plugin (see wrappedFileName func) if plugin wraps other plugin
+// and base name of Go file in output path folder otherwise (see getGoFileBase func).
+func (p *plugin) targetFileName(f *descriptor.FileDescriptorProto, suffix string) (string, error) {
+	if len(p.wrap) == 0 {
+		return p.getGoFileBase(f) + suffix, nil
+	}
+
+	name, err := p.wrappedFileName(f, suffix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get name of Go file is generated by wrapped plugin: %s", err.Error())
+	}
+	return name, nil
+}
+
+// getGoFileBase returns base name of Go files are generated for source proto file.
+// Example: Go file base name of 'test/data.proto' is 'data'.
+func (p *plugin) getGoFileBase(f *descriptor.FileDescriptorProto) string {
+	n := filepath.Base(f.GetName())
+	return strings.TrimSuffix(n, filepath.Ext(n))
+}
+
 // analyzeMessageType analyze proto Message:
 // - extracting field tags
 // - extracting OneOf tags
 // It drills down into nested proto Messages also.
-func (p *plugin) analyzeMessageType(file goFile, parents []string, message *descriptor.DescriptorProto) error {
+// path is the message location path in source proto file (see descriptor.SourceCodeInfo_Location).
+func (p *plugin) analyzeMessageType(file *protoFile, parents []string, path []int32, message *descriptor.DescriptorProto) error {
 	s := goStruct{}
-	goMes := p.toGolangStructName(parents, message.GetName())
+	goMes, err := p.names.structName(p.getMessageFullName(file, parents, message.GetName()))
+	if err != nil {
+		return err
+	}
 
-	if p.xxxTags != nil {
-		s["XXX_NoUnkeyedLiteral"] = p.xxxTags
-		s["XXX_unrecognized"] = p.xxxTags
-		s["XXX_sizecache"] = p.xxxTags
+	use, err := p.getExtension(message.GetOptions(), tagger.E_MessageUse)
+	if err != nil {
+		return fmt.Errorf("failed to get tag sets extension: %s", err.Error())
 	}
+	uses := parseList(use)
 
-	// scan proto message fields
-	for _, field := range message.GetField() {
-		var tag string
-		for _, k := range p.originalFieldNames {
-			if len(tag) > 0 {
-				tag += " "
+	skipMessage, err := p.getBoolExtension(message.GetOptions(), tagger.E_MessageSkipAuto)
+	if err != nil {
+		return fmt.Errorf("failed to get skip auto tags extension: %s", err.Error())
+	}
+	if !skipMessage {
+		skipMessage = p.matchSkipRules(p.getMessageFullName(file, parents, message.GetName()))
+	}
+
+	if p.xxxTags != nil {
+		for _, n := range []string{"XXX_NoUnkeyedLiteral", "XXX_unrecognized", "XXX_sizecache"} {
+			if p.generator == generatorGogo && !hasGogoXXX(file.desc, message, n) {
+				// XXX field is removed by gogoproto option
+				continue
 			}
-			tag += k + `:"` + field.GetName() + `"`
-		}
-		ofn, err := structtag.Parse(tag)
-		if err != nil {
-			return fmt.Errorf("failed to parse tags '%s': %s", tag, err.Error())
+			s[n] = &goField{tags: p.xxxTags, optional: true}
 		}
+	}
 
-		ext, err := p.getExtension(field.GetOptions(), tagger.E_Tags)
+	// scan proto message fields
+	for i, field := range message.GetField() {
+		skip, err := p.getBoolExtension(field.GetOptions(), tagger.E_SkipAuto)
 		if err != nil {
-			return fmt.Errorf("failed to get extension for field '%s' type '%s': %s",
+			return fmt.Errorf("failed to get skip auto tags extension for field '%s' type '%s': %s",
 				field.GetName(), p.getMessageURI(parents, message.GetName()), err.Error())
 		}
-		tags, err := structtag.Parse(ext)
-		if err != nil {
-			return fmt.Errorf("failed to parse tags '%s': %s", ext, err.Error())
+		skip = skip || skipMessage
+
+		auto := &structtag.Tags{}
+		var omitempty map[string]bool
+		if !skip {
+			auto = p.autoTags(field.GetName(), file.comments[locationKey(locationPath(path, fieldPath, i))])
+			if p.protojson {
+				auto.Set(p.protojsonTag(field))
+			}
+			for _, t := range p.numberTags {
+				auto.Set(&structtag.Tag{
+					Key:     t.Key,
+					Name:    strconv.Itoa(int(field.GetNumber())),
+					Options: append([]string(nil), t.Options...),
+				})
+			}
+			omitempty = p.omitemptyOptions(p.hasPresence(field))
 		}
 
-		if tags, err = p.concatTags(tags, ofn); err != nil {
-			return fmt.Errorf("failed to merge tag: %s", err.Error())
+		uri := p.getFieldURI(file, parents, message.GetName(), field.GetName())
+		n, err := p.names.fieldName(uri)
+		if err != nil {
+			return err
+		}
+		pf := &protoField{
+			opts: field.GetOptions(),
+			target: &TagTarget{
+				File: file.desc, Message: message, Field: field, FullName: uri, GoName: n,
+			},
+			exts: fieldExtensions,
+			uri:  uri,
+			uses: uses,
+			data: templateData{
+				Name:     field.GetName(),
+				JSONName: field.GetJsonName(),
+				Number:   field.GetNumber(),
+				GoName:   n,
+				Message:  message.GetName(),
+			},
+		}
+		tags, err := p.getTags(file, pf, auto)
+		if err != nil {
+			return fmt.Errorf("failed to get tags for field '%s': %s", pf.uri, err.Error())
 		}
 
-		if tags.Len() > 0 {
-			n := p.toGolangFieldName(field.GetName())
-			if field.OneofIndex != nil {
+		if tags.Len() > 0 || len(omitempty) > 0 {
+			f := &goField{tags: tags, omitempty: omitempty}
+			if wrapper, ok := p.names.wrappers[uri]; ok {
 				oneOf := goStruct{}
-				oneOf[n] = tags
-				file.structs[goMes+"_"+n] = oneOf
+				oneOf[n] = f
+				file.target.structs[wrapper] = oneOf
 			} else {
-				s[n] = tags
+				s[n] = f
 			}
 		}
 	}
 
 	// scan proto message oneOfs
-	for _, oneOf := range message.GetOneofDecl() {
-		var tag string
-		for _, k := range p.originalFieldNames {
-			if len(s) > 0 {
-				tag += " "
-			}
-			tag += k + `:"` + oneOf.GetName() + `"`
-		}
-		ofn, err := structtag.Parse(tag)
-		if err != nil {
-			return fmt.Errorf("failed to parse tags '%s': %s", tag, err.Error())
+	for i, oneOf := range message.GetOneofDecl() {
+		if p.isSyntheticOneof(message, i) {
+			// proto3 optional field is generated as regular Go struct field
+			continue
 		}
 
-		ext, err := p.getExtension(oneOf.GetOptions(), tagger.E_OneofTags)
+		skip, err := p.getBoolExtension(oneOf.GetOptions(), tagger.E_OneofSkipAuto)
 		if err != nil {
-			return fmt.Errorf("failed to get extension for oneof '%s' type '%s': %s",
+			return fmt.Errorf("failed to get skip auto tags extension for oneof '%s' type '%s': %s",
 				oneOf.GetName(), p.getMessageURI(parents, message.GetName()), err.Error())
 		}
-		tags, err := structtag.Parse(ext)
-		if err != nil {
-			return fmt.Errorf("failed to parse tags '%s': %s", ext, err.Error())
+		skip = skip || skipMessage
+
+		auto := &structtag.Tags{}
+		var omitempty map[string]bool
+		if !skip {
+			auto = p.autoTags(oneOf.GetName(), file.comments[locationKey(locationPath(path, oneofDeclPath, i))])
+			// oneof always has presence
+			omitempty = p.omitemptyOptions(true)
 		}
 
-		if tags, err = p.concatTags(tags, ofn); err != nil {
-			return fmt.Errorf("failed to merge tag: %s", err.Error())
+		uri := p.getFieldURI(file, parents, message.GetName(), oneOf.GetName())
+		n, err := p.names.fieldName(uri)
+		if err != nil {
+			return err
+		}
+		pf := &protoField{
+			opts: oneOf.GetOptions(),
+			target: &TagTarget{
+				File: file.desc, Message: message, Oneof: oneOf, FullName: uri, GoName: n,
+			},
+			exts: oneofExtensions,
+			uri:  uri,
+			uses: uses,
+			data: templateData{
+				Name:    oneOf.GetName(),
+				GoName:  n,
+				Message: message.GetName(),
+			},
+		}
+		tags, err := p.getTags(file, pf, auto)
+		if err != nil {
+			return fmt.Errorf("failed to get tags for oneof '%s': %s", pf.uri, err.Error())
 		}
 
-		if tags.Len() > 0 {
-			s[p.toGolangFieldName(oneOf.GetName())] = tags
+		if tags.Len() > 0 || len(omitempty) > 0 {
+			s[n] = &goField{tags: tags, omitempty: omitempty}
 		}
 	}
 
 	// scan nested proto messages
-	for _, m := range message.GetNestedType() {
+	for i, m := range message.GetNestedType() {
 		ps := make([]string, len(parents), len(parents)+1)
 		copy(ps, parents)
 		ps = append(ps, message.GetName())
-		if err := p.analyzeMessageType(file, ps, m); err != nil {
+		if err := p.analyzeMessageType(file, ps, locationPath(path, nestedTypePath, i), m); err != nil {
 			return fmt.Errorf("failed to analyze message type '%s': %s", p.getMessageURI(ps, m.GetName()), err.Error())
 		}
 	}
 
 	if len(s) > 0 {
-		file.structs[goMes] = s
+		file.target.structs[goMes] = s
+	}
+
+	return nil
+}
+
+// autoTags returns tags are derived from proto field or oneof automatically:
+// - original_field_names: tag name is equal to proto field (oneof) name
+// - comment_tags: tag value is equal to proto field (oneof) leading comment
+func (p *plugin) autoTags(name string, comment string) *structtag.Tags {
+	tags := &structtag.Tags{}
+
+	for _, k := range p.originalFieldNames {
+		tags.Set(&structtag.Tag{Key: k, Name: name})
+	}
+
+	if len(comment) > 0 {
+		for _, k := range p.commentTags {
+			tags.Set(&structtag.Tag{Key: k, Name: comment})
+		}
+	}
+
+	return tags
+}
+
+// protojsonTag returns json tag is matching protojson serialization of the field:
+// - tag name is equal to proto field json_name (lowerCamelCase by default)
+// - 64-bit integer fields are serialized as strings
+// - 'omitempty' option is added unless json_emit_defaults parameter is set
+func (p *plugin) protojsonTag(field *descriptor.FieldDescriptorProto) *structtag.Tag {
+	name := field.GetJsonName()
+	if len(name) == 0 {
+		name = field.GetName()
+	}
+
+	t := &structtag.Tag{Key: "json", Name: name}
+
+	if field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		switch field.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_INT64,
+			descriptor.FieldDescriptorProto_TYPE_UINT64,
+			descriptor.FieldDescriptorProto_TYPE_SINT64,
+			descriptor.FieldDescriptorProto_TYPE_FIXED64,
+			descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+			t.Options = append(t.Options, "string")
+		}
+	}
+
+	if !p.jsonEmitDefaults {
+		t.Options = append(t.Options, "omitempty")
+	}
+
+	return t
+}
+
+// omitemptyOptions returns map of <tag key>-><true to add, false to remove> 'omitempty' option
+// according to 'omitempty' parameter policies.
+// presence is true if proto field tracks presence (see plugin.hasPresence func).
+func (p *plugin) omitemptyOptions(presence bool) map[string]bool {
+	if len(p.omitempty) == 0 {
+		return nil
+	}
+
+	opts := make(map[string]bool, len(p.omitempty))
+	for k, policy := range p.omitempty {
+		switch policy {
+		case omitemptyAlways:
+			opts[k] = true
+		case omitemptyPresence:
+			opts[k] = presence
+		case omitemptyNever:
+			opts[k] = false
+		}
+	}
+
+	return opts
+}
+
+// hasPresence returns true if proto field tracks presence:
+// - proto3 optional field
+// - message field
+// - oneof field
+func (p *plugin) hasPresence(field *descriptor.FieldDescriptorProto) bool {
+	if field.GetProto3Optional() || field.OneofIndex != nil {
+		return true
+	}
+
+	return field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED &&
+		field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE
+}
+
+// isSyntheticOneof returns true if oneof (provided by index) is synthetic oneof is generated by protoc for proto3 optional field.
+// Go struct does not contain field for synthetic oneof.
+func (p *plugin) isSyntheticOneof(message *descriptor.DescriptorProto, index int) bool {
+	for _, field := range message.GetField() {
+		if field.OneofIndex != nil && int(field.GetOneofIndex()) == index {
+			return field.GetProto3Optional()
+		}
+	}
+
+	return false
+}
+
+// getTags returns tags of proto field (oneof) are merged from:
+// - explicit tags are provided by field (oneof) options (e.g. (tagger.tags) and (tagger.field))
+// - tag sets are referenced by field (oneof) and message options (e.g. (tagger.use))
+// - tags are derived automatically (provided by 'auto')
+// Tags are listed in priority order. Tags of hidden keys are replaced by '-' name.
+func (p *plugin) getTags(file *protoFile, f *protoField, auto *structtag.Tags) (*structtag.Tags, error) {
+	ext, err := p.getExtension(f.opts, f.exts.tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags extension: %s", err.Error())
+	}
+	tags, err := structtag.Parse(ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tags '%s': %s", ext, err.Error())
+	}
+
+	fields, err := p.getStructuredTags(f.opts, f.exts.field)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get structured tags extension: %s", err.Error())
+	}
+	var fieldHidden []string
+	for _, field := range fields {
+		if !p.isActiveProfile(field.GetProfile()) {
+			continue
+		}
+		if tags, err = p.mergeStructuredTags(tags, field); err != nil {
+			return nil, fmt.Errorf("failed to merge structured tags: %s", err.Error())
+		}
+		fieldHidden = append(fieldHidden, field.GetHide()...)
+	}
+
+	if tags, err = p.mergeProviderTags(tags, f.target); err != nil {
+		return nil, err
+	}
+
+	use, err := p.getExtension(f.opts, f.exts.use)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag sets extension: %s", err.Error())
+	}
+	names := append(append([]string{}, f.uses...), parseList(use)...)
+	setTags, setHidden, err := p.applyTagSets(file.tagSets, names, &f.data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply tag sets: %s", err.Error())
+	}
+
+	hide, err := p.getExtension(f.opts, f.exts.hide)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hide extension: %s", err.Error())
+	}
+	hidden := p.getHiddenKeys(f.uri, append(append(parseList(hide), fieldHidden...), setHidden...))
+	if err = p.validateHidden(tags, hidden); err != nil {
+		return nil, err
+	}
+
+	if auto, err = p.concatTags(setTags, auto); err != nil {
+		return nil, fmt.Errorf("failed to merge tag sets: %s", err.Error())
+	}
+	if tags, err = p.concatTags(tags, auto); err != nil {
+		return nil, fmt.Errorf("failed to merge tag: %s", err.Error())
+	}
+
+	return p.hideTags(tags, hidden), nil
+}
+
+// mergeStructuredTags adds structured tags (provided by (tagger.field) option) to tags
+// (provided by (tagger.tags) option and other (tagger.field) options). It returns error
+// if tags contain the same tag key with different values.
+func (p *plugin) mergeStructuredTags(tags *structtag.Tags, field *tagger.Field) (*structtag.Tags, error) {
+	if tags == nil {
+		tags = &structtag.Tags{}
+	}
+
+	for _, st := range field.GetTag() {
+		if len(st.GetKey()) == 0 {
+			return nil, fmt.Errorf("structured tag key is empty")
+		}
+
+		t := &structtag.Tag{Key: st.GetKey(), Name: st.GetName()}
+		if len(st.GetOptions()) > 0 {
+			t.Options = append([]string(nil), st.GetOptions()...)
+		}
+
+		if err := mergeTag(tags, t); err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+// mergeTag adds tag to tags if tags don't contain its key.
+// It returns error if tags contain the same tag key with different value.
+func mergeTag(tags *structtag.Tags, t *structtag.Tag) error {
+	if old, err := tags.Get(t.Key); err == nil {
+		if old.Value() != t.Value() {
+			return fmt.Errorf("tag key '%s' has conflicting values '%s' and '%s'", t.Key, old.Value(), t.Value())
+		}
+		return nil
+	}
+
+	return tags.Set(t)
+}
+
+// getHiddenKeys returns tag keys to hide proto field (oneof) from.
+// Keys are provided by field (oneof) options (provided by 'keys')
+// and by 'hide' parameter rules are matching field (oneof) URI.
+func (p *plugin) getHiddenKeys(uri string, keys []string) []string {
+	for _, r := range p.hideRules {
+		if ok, _ := path.Match(r.pattern, uri); ok {
+			keys = append(keys, r.keys...)
+		}
+	}
+
+	return keys
+}
+
+// validateHidden returns error if explicit tags (provided by field or oneof option)
+// contain names for hidden tag keys. Such tags are contradictory.
+func (p *plugin) validateHidden(explicit *structtag.Tags, hidden []string) error {
+	if explicit == nil {
+		return nil
+	}
+
+	for _, k := range hidden {
+		if t, err := explicit.Get(k); err == nil && len(t.Name) > 0 && t.Name != "-" {
+			return fmt.Errorf("tag key '%s' is hidden but has explicit name '%s'", k, t.Name)
+		}
 	}
 
 	return nil
 }
 
+// hideTags sets '-' tag name for hidden tag keys.
+func (p *plugin) hideTags(tags *structtag.Tags, hidden []string) *structtag.Tags {
+	if len(hidden) == 0 {
+		return tags
+	}
+
+	if tags == nil {
+		tags = &structtag.Tags{}
+	}
+	for _, k := range hidden {
+		tags.Set(&structtag.Tag{Key: k, Name: "-"})
+	}
+
+	return tags
+}
+
 // concatTags concatenates two tags.
 // tags1 has priority. It means tags2 does not override tags1.
 func (p *plugin) concatTags(tags1 *structtag.Tags, tags2 *structtag.Tags) (*structtag.Tags, error) {
-	if tags1.Len() == 0 {
+	if tags1 == nil || tags1.Len() == 0 {
 		return tags2, nil
 	}
 	if tags2.Len() == 0 {
@@ -188,11 +625,8 @@ func (p *plugin) concatTags(tags1 *structtag.Tags, tags2 *structtag.Tags) (*stru
 			}
 		}
 		if !found {
-			var err error
-			s := tags1.String() + " " + t2.String()
-			tags1, err = structtag.Parse(s)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse tags '%s': %s", s, err.Error())
+			if err := tags1.Set(t2); err != nil {
+				return nil, fmt.Errorf("failed to add tag '%s': %s", t2.String(), err.Error())
 			}
 		}
 	}
@@ -231,6 +665,88 @@ func (p *plugin) getExtension(opts proto.Message, ext *proto.ExtensionDesc) (str
 	return s, nil
 }
 
+// getFieldURI construct full field (oneof) name is used for matching rules and error logging
+// Example: URI of field 'val3' of 'Data1.Data2' proto message in 'test' package is 'test.Data1.Data2.val3'.
+func (p *plugin) getFieldURI(file *protoFile, parents []string, message string, field string) string {
+	return p.getMessageFullName(file, parents, message) + "." + field
+}
+
+// getMessageFullName construct full proto message name is used for matching rules
+// Example: full name of 'Data1.Data2' proto message in 'test' package is 'test.Data1.Data2'.
+func (p *plugin) getMessageFullName(file *protoFile, parents []string, message string) string {
+	name := p.getMessageURI(parents, message)
+	if pkg := file.desc.GetPackage(); len(pkg) > 0 {
+		name = pkg + "." + name
+	}
+	return name
+}
+
+// matchSkipRules returns true if full proto message name matches one of 'skip' parameter patterns
+func (p *plugin) matchSkipRules(name string) bool {
+	for _, pattern := range p.skipPatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isActiveProfile returns true if annotation (provided by its profiles) is active:
+// annotation has no profiles or one of its profiles is selected by 'profile' parameter.
+func (p *plugin) isActiveProfile(profiles []string) bool {
+	if len(profiles) == 0 {
+		return true
+	}
+
+	for _, a := range profiles {
+		for _, b := range p.profiles {
+			if a == b {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getStructuredTags extract structured tags (proto extension) from field (oneof) options.
+func (p *plugin) getStructuredTags(opts proto.Message, ext *proto.ExtensionDesc) ([]*tagger.Field, error) {
+	if opts == nil || !proto.HasExtension(opts, ext) {
+		return nil, nil
+	}
+
+	val, err := proto.GetExtension(opts, ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extension: %s", err.Error())
+	}
+
+	fields, ok := val.([]*tagger.Field)
+	if !ok {
+		return nil, fmt.Errorf("cannot assign extension type '%T' to output type '[]*tagger.Field'", val)
+	}
+
+	return fields, nil
+}
+
+// getBoolExtension extract flag (proto extension) from options.
+func (p *plugin) getBoolExtension(opts proto.Message, ext *proto.ExtensionDesc) (bool, error) {
+	if opts == nil || !proto.HasExtension(opts, ext) {
+		return false, nil
+	}
+
+	val, err := proto.GetExtension(opts, ext)
+	if err != nil {
+		return false, fmt.Errorf("failed to get extension: %s", err.Error())
+	}
+
+	b, ok := val.(*bool)
+	if !ok {
+		return false, fmt.Errorf("cannot assign extension type '%T' to output type '*bool'", val)
+	}
+
+	return *b, nil
+}
+
 // getMessageURI construct message URI is used for error logging
 // Example of proto:
 // message Data1 {
@@ -246,3 +762,36 @@ func (p *plugin) getMessageURI(parents []string, message string) string {
 	}
 	return res + message
 }
+
+// getComments returns map of <location path>-><normalized leading comment> for source proto file.
+// Location path is built by locationKey func.
+func (p *plugin) getComments(f *descriptor.FileDescriptorProto) map[string]string {
+	comments := map[string]string{}
+
+	for _, l := range f.GetSourceCodeInfo().GetLocation() {
+		c := strings.Join(strings.Fields(l.GetLeadingComments()), " ")
+		if len(c) > 0 {
+			comments[locationKey(l.GetPath())] = c
+		}
+	}
+
+	return comments
+}
+
+// locationPath returns location path of the child element (e.g. field, oneof, nested message)
+// Example: path of the 2nd field of 1st message in the file is [4, 0, 2, 1].
+func locationPath(parent []int32, kind int32, index int) []int32 {
+	path := make([]int32, len(parent), len(parent)+2)
+	copy(path, parent)
+	return append(path, kind, int32(index))
+}
+
+// locationKey returns string key of location path is used to look up source proto file comments.
+func locationKey(path []int32) string {
+	ss := make([]string, len(path))
+	for i, v := range path {
+		ss[i] = strconv.Itoa(int(v))
+	}
+
+	return strings.Join(ss, ",")
+}
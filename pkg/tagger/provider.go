@@ -0,0 +1,96 @@
+package tagger
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fatih/structtag"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// TagProvider derives tags for proto fields and oneofs (e.g. from custom proto options).
+// Provided tags are merged with tags of (tagger.tags) and (tagger.field) options the same way:
+// the same tag key with different values is an error. Tag sets, automatically derived tags
+// and hide rules are applied to the merged tags.
+type TagProvider interface {
+	// Tags returns tags in struct tag format (e.g. spanner:"Name") for proto field or oneof.
+	// It returns empty string if there are no tags for the field (oneof).
+	Tags(target *TagTarget) (string, error)
+}
+
+// TagTarget describes proto field or oneof to derive tags for
+type TagTarget struct {
+	// File is source proto file
+	File *descriptor.FileDescriptorProto
+
+	// Message is proto message the field (oneof) belongs to
+	Message *descriptor.DescriptorProto
+
+	// Field is proto field (it is nil for oneof)
+	Field *descriptor.FieldDescriptorProto
+
+	// Oneof is proto oneof (it is nil for field)
+	Oneof *descriptor.OneofDescriptorProto
+
+	// FullName is full proto field (oneof) name (e.g. test.Data.val_vvall)
+	FullName string
+
+	// GoName is Go struct field name (e.g. ValVvall)
+	GoName string
+}
+
+var (
+	// providersMu guards providers
+	providersMu sync.Mutex
+
+	// providers are tag providers are registered by RegisterTagProvider func
+	providers []TagProvider
+)
+
+// RegisterTagProvider registers tag provider for every plugin.
+// It is usually called from init func of package that implements the provider.
+func RegisterTagProvider(provider TagProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers = append(providers, provider)
+}
+
+// registeredTagProviders returns tag providers are registered by RegisterTagProvider func
+func registeredTagProviders() []TagProvider {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	return append([]TagProvider(nil), providers...)
+}
+
+// mergeProviderTags adds tags of tag providers for proto field (oneof) to tags
+// (provided by (tagger.tags) and (tagger.field) options). It returns error
+// if tags contain the same tag key with different values.
+func (p *plugin) mergeProviderTags(tags *structtag.Tags, target *TagTarget) (*structtag.Tags, error) {
+	if tags == nil {
+		tags = &structtag.Tags{}
+	}
+
+	for _, provider := range p.providers {
+		s, err := provider.Tags(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags of provider '%T': %s", provider, err.Error())
+		}
+		pt, err := structtag.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tags '%s' of provider '%T': %s", s, provider, err.Error())
+		}
+		if pt == nil {
+			continue
+		}
+
+		for _, t := range pt.Tags() {
+			if err = mergeTag(tags, t); err != nil {
+				return nil, fmt.Errorf("failed to merge tags of provider '%T': %s", provider, err.Error())
+			}
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,76 @@
+package tagger_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+// columnProvider derives spanner column tags from proto field names
+type columnProvider struct{}
+
+func (columnProvider) Tags(target *tagger.TagTarget) (string, error) {
+	if target.Field == nil || target.Message.GetName() != "Data" {
+		return "", nil
+	}
+	name := target.Field.GetName()
+	return fmt.Sprintf(`spanner:"%s"`, strings.ToUpper(name[:1])+name[1:]), nil
+}
+
+// bsonProvider returns bson tag that conflicts with (tagger.tags) one of test.Data.val_vvall
+type bsonProvider struct{}
+
+func (bsonProvider) Tags(target *tagger.TagTarget) (string, error) {
+	return fmt.Sprintf(`bson:"%s"`, target.GoName), nil
+}
+
+// failedProvider always fails
+type failedProvider struct{}
+
+func (failedProvider) Tags(target *tagger.TagTarget) (string, error) {
+	return "", fmt.Errorf("no column of '%s'", target.FullName)
+}
+
+func TestTagProviders(t *testing.T) {
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	goFiles := map[string]string{"data.pb.go": goFixture(t, "data.pb.go")}
+
+	taggertest.Run(t, []taggertest.Case{
+		{
+			Name:      "provider tags",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Go:        goFiles,
+			Providers: []tagger.TagProvider{columnProvider{}},
+			Want: map[string]map[string]string{
+				"Data": {
+					"ValVvall": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty" spanner:"Val_vvall"`,
+					// hide rules are applied to merged tags
+					"Password": `protobuf:"bytes,10,opt,name=password,proto3" json:"-" spanner:"Password" bson:"-"`,
+				},
+				"Data_A": {
+					"A": `protobuf:"bytes,5,opt,name=a,proto3,oneof" bson:"A" spanner:"A"`,
+				},
+			},
+		},
+		{
+			Name:      "conflicting tags",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Go:        goFiles,
+			Providers: []tagger.TagProvider{bsonProvider{}},
+			WantErr:   "failed to merge tags of provider 'tagger_test.bsonProvider': tag key 'bson' has conflicting values 'name12,omitempty' and 'ValVvall'",
+		},
+		{
+			Name:      "provider error",
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Go:        goFiles,
+			Providers: []tagger.TagProvider{failedProvider{}},
+			WantErr:   "failed to get tags of provider 'tagger_test.failedProvider': no column of 'test.Data.val_vvall'",
+		},
+	})
+}
@@ -0,0 +1,188 @@
+package tagger
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/fatih/structtag"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+)
+
+// tagSet is compiled named set of tags (see tagger.TagSet proto message)
+type tagSet struct {
+	// tags is template of tags in struct tag format
+	tags *template.Template
+
+	// tag contains templates of structured tags
+	tag []tagTemplate
+
+	// hide contains tag keys to hide field from
+	hide []string
+
+	// profiles contains profiles the tag set is active for
+	profiles []string
+}
+
+// tagTemplate is template of structured tag
+type tagTemplate struct {
+	// key is tag key (e.g. bson)
+	key string
+
+	// value is template of tag value (name and options delimited by comma)
+	value *template.Template
+}
+
+// templateData contains proto field (oneof) data are available in tag set templates.
+// Example: graphql:"{{.Name}},optional"
+type templateData struct {
+	// Name is proto field (oneof) name
+	Name string
+
+	// JSONName is proto field JSON name (it is empty for oneof)
+	JSONName string
+
+	// Number is proto field number (it is 0 for oneof)
+	Number int32
+
+	// GoName is Go struct field name
+	GoName string
+
+	// Message is proto message name
+	Message string
+}
+
+// getTagSets returns tag sets are available for source proto file.
+// Tag sets are defined in the file and in the files it imports directly.
+// Tag sets of the file override imported tag sets with the same names.
+func (p *plugin) getTagSets(f *descriptor.FileDescriptorProto) (map[string]*tagSet, error) {
+	sets := map[string]*tagSet{}
+
+	for _, dep := range f.GetDependency() {
+		for _, d := range p.request.GetProtoFile() {
+			if d.GetName() != dep {
+				continue
+			}
+			depSets, err := p.loadTagSets(d)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load tag sets of imported file '%s': %s", dep, err.Error())
+			}
+			for n, s := range depSets {
+				sets[n] = s
+			}
+		}
+	}
+
+	own, err := p.loadTagSets(f)
+	if err != nil {
+		return nil, err
+	}
+	for n, s := range own {
+		sets[n] = s
+	}
+
+	return sets, nil
+}
+
+// loadTagSets compiles tag sets are defined by proto file option (see 'tag_set' option)
+func (p *plugin) loadTagSets(f *descriptor.FileDescriptorProto) (map[string]*tagSet, error) {
+	sets := map[string]*tagSet{}
+
+	opts := f.GetOptions()
+	if opts == nil || !proto.HasExtension(opts, tagger.E_TagSet) {
+		return sets, nil
+	}
+
+	val, err := proto.GetExtension(opts, tagger.E_TagSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag sets extension: %s", err.Error())
+	}
+	defs, ok := val.([]*tagger.TagSet)
+	if !ok {
+		return nil, fmt.Errorf("cannot assign extension type '%T' to output type '[]*tagger.TagSet'", val)
+	}
+
+	for _, def := range defs {
+		n := def.GetName()
+		if len(n) == 0 {
+			return nil, fmt.Errorf("tag set name is empty")
+		}
+		if _, ok := sets[n]; ok {
+			return nil, fmt.Errorf("duplicate tag set '%s'", n)
+		}
+
+		s := &tagSet{hide: def.GetHide(), profiles: def.GetProfile()}
+		if s.tags, err = template.New(n).Parse(def.GetTags()); err != nil {
+			return nil, fmt.Errorf("failed to parse tags template of tag set '%s': %s", n, err.Error())
+		}
+		for _, t := range def.GetTag() {
+			if len(t.GetKey()) == 0 {
+				return nil, fmt.Errorf("structured tag key of tag set '%s' is empty", n)
+			}
+			v := strings.Join(append([]string{t.GetName()}, t.GetOptions()...), ",")
+			tt, err := template.New(n + "." + t.GetKey()).Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse tag '%s' template of tag set '%s': %s", t.GetKey(), n, err.Error())
+			}
+			s.tag = append(s.tag, tagTemplate{key: t.GetKey(), value: tt})
+		}
+
+		sets[n] = s
+	}
+
+	return sets, nil
+}
+
+// applyTagSets expands tag sets (provided by names) for proto field (oneof).
+// It returns tags and hidden tag keys of the tag sets.
+// Tag sets are applied in order, so the next tag set overrides tags of the previous one.
+// Tag sets are not active for selected profiles are skipped.
+func (p *plugin) applyTagSets(sets map[string]*tagSet, names []string, data *templateData) (*structtag.Tags, []string, error) {
+	tags := &structtag.Tags{}
+	var hidden []string
+
+	for _, n := range names {
+		s, ok := sets[n]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown tag set '%s'", n)
+		}
+		if !p.isActiveProfile(s.profiles) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := s.tags.Execute(&buf, data); err != nil {
+			return nil, nil, fmt.Errorf("failed to expand tags of tag set '%s': %s", n, err.Error())
+		}
+		ts, err := structtag.Parse(buf.String())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse tags '%s' of tag set '%s': %s", buf.String(), n, err.Error())
+		}
+		if ts != nil {
+			for _, t := range ts.Tags() {
+				tags.Set(t)
+			}
+		}
+
+		for _, tt := range s.tag {
+			buf.Reset()
+			if err := tt.value.Execute(&buf, data); err != nil {
+				return nil, nil, fmt.Errorf("failed to expand tag '%s' of tag set '%s': %s", tt.key, n, err.Error())
+			}
+			ss := strings.Split(buf.String(), ",")
+			t := &structtag.Tag{Key: tt.key, Name: ss[0]}
+			if len(ss) > 1 {
+				t.Options = ss[1:]
+			}
+			tags.Set(t)
+		}
+
+		hidden = append(hidden, s.hide...)
+	}
+
+	return tags, hidden, nil
+}
@@ -0,0 +1,72 @@
+package tagger
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// goFileSuffix is suffix of Go files are generated by protoc-gen-go
+const goFileSuffix = ".pb.go"
+
+// target is target file with Go structs are generated by other Go plugin (e.g. vtproto, custom generators)
+// for proto messages (see 'targets' parameter)
+type target struct {
+	// suffix is Go file name suffix (e.g. .pb.dto.go)
+	suffix string
+
+	// name is Go struct name template (e.g. {{.GoName}}DTO)
+	name *template.Template
+}
+
+// targetData contains data are available in target struct name template
+type targetData struct {
+	// GoName is Go struct name is generated by protoc-gen-go (e.g. Data_Nested)
+	GoName string
+}
+
+// newTarget returns target for Go file name suffix and struct name template.
+// Default struct name template is '{{.GoName}}'.
+func newTarget(suffix string, name string) (target, error) {
+	if len(name) == 0 {
+		name = "{{.GoName}}"
+	}
+	t, err := template.New(suffix).Parse(name)
+	if err != nil {
+		return target{}, fmt.Errorf("failed to parse struct name template '%s': %s", name, err.Error())
+	}
+	return target{suffix: suffix, name: t}, nil
+}
+
+// addTargets stores data to update Go structs of target files are generated for source proto file.
+// Go structs of target files get the same field tags as structs of generated Go file (provided by file).
+// Target files, structs and fields are optional, so they are skipped if they are not found.
+func (p *plugin) addTargets(f *descriptor.FileDescriptorProto, file goFile) error {
+	for _, t := range p.targets {
+		name, err := p.targetFileName(f, t.suffix)
+		if err != nil {
+			return err
+		}
+
+		tf := goFile{structs: map[string]goStruct{}}
+		for n, s := range file.structs {
+			var buf bytes.Buffer
+			if err := t.name.Execute(&buf, targetData{GoName: n}); err != nil {
+				return fmt.Errorf("failed to build struct name of '%s' for target '%s': %s", n, t.suffix, err.Error())
+			}
+
+			ts := goStruct{}
+			for fn, f := range s {
+				ts[fn] = &goField{tags: f.tags, omitempty: f.omitempty, optional: true}
+			}
+			tf.structs[buf.String()] = ts
+		}
+		tf.optional = true
+
+		p.targetFiles[name] = tf
+	}
+
+	return nil
+}
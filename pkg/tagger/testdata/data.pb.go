@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: data.proto
+
+package test
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Data is test message.
+type Data struct {
+	// val_vvall is "string" value
+	// with `multiline` comment.
+	ValVvall string `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty"`
+	// one_of is oneof value.
+	//
+	// Types that are valid to be assigned to OneOf:
+	//	*Data_A
+	//	*Data_BJk
+	OneOf                isData_OneOf `protobuf_oneof:"one_of"`
+	NestedValue          *DataNested  `protobuf:"bytes,7,opt,name=nested_value,json=nestedValue,proto3" json:"nested_value,omitempty"`
+	Int64Value           int64        `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty"`
+	Uint64Values         []uint64     `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values,omitempty"`
+	Password             string       `protobuf:"bytes,10,opt,name=password,proto3" json:"password,omitempty"`
+	Structured           string       `protobuf:"bytes,11,opt,name=structured,proto3" json:"structured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Data) Reset()         { *m = Data{} }
+func (m *Data) String() string { return proto.CompactTextString(m) }
+func (*Data) ProtoMessage()    {}
+func (*Data) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{0}
+}
+
+func (m *Data) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Data.Unmarshal(m, b)
+}
+func (m *Data) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Data.Marshal(b, m, deterministic)
+}
+func (m *Data) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Data.Merge(m, src)
+}
+func (m *Data) XXX_Size() int {
+	return xxx_messageInfo_Data.Size(m)
+}
+func (m *Data) XXX_DiscardUnknown() {
+	xxx_messageInfo_Data.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Data proto.InternalMessageInfo
+
+func (m *Data) GetValVvall() string {
+	if m != nil {
+		return m.ValVvall
+	}
+	return ""
+}
+
+type isData_OneOf interface {
+	isData_OneOf()
+}
+
+type Data_A struct {
+	A string `protobuf:"bytes,5,opt,name=a,proto3,oneof"`
+}
+
+type Data_BJk struct {
+	BJk int32 `protobuf:"varint,6,opt,name=b_jk,json=bJk,proto3,oneof"`
+}
+
+func (*Data_A) isData_OneOf() {}
+
+func (*Data_BJk) isData_OneOf() {}
+
+func (m *Data) GetOneOf() isData_OneOf {
+	if m != nil {
+		return m.OneOf
+	}
+	return nil
+}
+
+func (m *Data) GetA() string {
+	if x, ok := m.GetOneOf().(*Data_A); ok {
+		return x.A
+	}
+	return ""
+}
+
+func (m *Data) GetBJk() int32 {
+	if x, ok := m.GetOneOf().(*Data_BJk); ok {
+		return x.BJk
+	}
+	return 0
+}
+
+func (m *Data) GetNestedValue() *DataNested {
+	if m != nil {
+		return m.NestedValue
+	}
+	return nil
+}
+
+func (m *Data) GetInt64Value() int64 {
+	if m != nil {
+		return m.Int64Value
+	}
+	return 0
+}
+
+func (m *Data) GetUint64Values() []uint64 {
+	if m != nil {
+		return m.Uint64Values
+	}
+	return nil
+}
+
+func (m *Data) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *Data) GetStructured() string {
+	if m != nil {
+		return m.Structured
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Data) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Data_A)(nil),
+		(*Data_BJk)(nil),
+	}
+}
+
+type DataNested struct {
+	XVal2Value           string   `protobuf:"bytes,1,opt,name=__val2_value,json=Val2Value,proto3" json:"__val2_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataNested) Reset()         { *m = DataNested{} }
+func (m *DataNested) String() string { return proto.CompactTextString(m) }
+func (*DataNested) ProtoMessage()    {}
+func (*DataNested) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{0, 0}
+}
+
+func (m *DataNested) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataNested.Unmarshal(m, b)
+}
+func (m *DataNested) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataNested.Marshal(b, m, deterministic)
+}
+func (m *DataNested) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataNested.Merge(m, src)
+}
+func (m *DataNested) XXX_Size() int {
+	return xxx_messageInfo_DataNested.Size(m)
+}
+func (m *DataNested) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataNested.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataNested proto.InternalMessageInfo
+
+func (m *DataNested) GetXVal2Value() string {
+	if m != nil {
+		return m.XVal2Value
+	}
+	return ""
+}
+
+type DataNested_OneMore_Nested struct {
+	// val3 is nested value.
+	Val3                 string   `protobuf:"bytes,1,opt,name=val3,proto3" json:"val3,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataNested_OneMore_Nested) Reset()         { *m = DataNested_OneMore_Nested{} }
+func (m *DataNested_OneMore_Nested) String() string { return proto.CompactTextString(m) }
+func (*DataNested_OneMore_Nested) ProtoMessage()    {}
+func (*DataNested_OneMore_Nested) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{0, 0, 0}
+}
+
+func (m *DataNested_OneMore_Nested) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataNested_OneMore_Nested.Unmarshal(m, b)
+}
+func (m *DataNested_OneMore_Nested) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataNested_OneMore_Nested.Marshal(b, m, deterministic)
+}
+func (m *DataNested_OneMore_Nested) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataNested_OneMore_Nested.Merge(m, src)
+}
+func (m *DataNested_OneMore_Nested) XXX_Size() int {
+	return xxx_messageInfo_DataNested_OneMore_Nested.Size(m)
+}
+func (m *DataNested_OneMore_Nested) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataNested_OneMore_Nested.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataNested_OneMore_Nested proto.InternalMessageInfo
+
+func (m *DataNested_OneMore_Nested) GetVal3() string {
+	if m != nil {
+		return m.Val3
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Data)(nil), "test.Data")
+	proto.RegisterType((*DataNested)(nil), "test.Data.nested")
+	proto.RegisterType((*DataNested_OneMore_Nested)(nil), "test.Data.nested.OneMore_Nested")
+}
+
+func init() { proto.RegisterFile("data.proto", fileDescriptor_871986018790d2fd) }
+
+var fileDescriptor_871986018790d2fd = []byte{
+	// 563 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xad, 0xb1, 0xf3, 0x35, 0x49, 0x91, 0xba, 0x05, 0x64, 0x59, 0x6a, 0xb1, 0xdc, 0x4b, 0x84,
+	0x42, 0x20, 0x6e, 0xd5, 0x43, 0x25, 0x0e, 0x44, 0x1c, 0xaa, 0xa2, 0x16, 0xc9, 0xa0, 0x72, 0xb4,
+	0xc6, 0xf5, 0x92, 0xb8, 0xb1, 0xbd, 0xae, 0x77, 0xed, 0x82, 0xaa, 0xdc, 0xfc, 0x0f, 0xb8, 0x71,
+	0xc8, 0x81, 0x23, 0x47, 0x4e, 0xfc, 0x0c, 0x7e, 0x12, 0x5a, 0x6f, 0x9a, 0x04, 0x09, 0x4e, 0x6b,
+	0xcd, 0xbe, 0x79, 0xef, 0xcd, 0xdb, 0x31, 0x40, 0x88, 0x02, 0x87, 0x59, 0xce, 0x04, 0x23, 0x86,
+	0xa0, 0x5c, 0x58, 0xbb, 0x02, 0x27, 0x13, 0x9a, 0xbf, 0x50, 0x87, 0xba, 0x72, 0x7e, 0x37, 0xc0,
+	0x78, 0x83, 0x02, 0xc9, 0x7b, 0xe8, 0x94, 0x18, 0xfb, 0x65, 0x89, 0x71, 0x6c, 0x6a, 0xb6, 0xd6,
+	0xef, 0x8c, 0x8f, 0xbf, 0x55, 0x0b, 0x7d, 0x34, 0xc9, 0x31, 0x9b, 0xde, 0xc4, 0x27, 0x4e, 0x8a,
+	0x09, 0x1d, 0x8d, 0x06, 0x2c, 0x13, 0x11, 0x4b, 0x31, 0x76, 0xec, 0x80, 0xb3, 0x74, 0x59, 0x76,
+	0x07, 0x2c, 0x89, 0x04, 0x4d, 0x32, 0xf1, 0xc5, 0xf1, 0xda, 0x25, 0xc6, 0x97, 0x92, 0x87, 0xec,
+	0x81, 0x86, 0x66, 0xa3, 0x26, 0xdb, 0x96, 0x64, 0x6d, 0xd5, 0xf2, 0xda, 0x39, 0xdd, 0xf2, 0x34,
+	0x24, 0xcf, 0xc0, 0x08, 0xfc, 0xeb, 0x99, 0xd9, 0xb4, 0xb5, 0x7e, 0x63, 0xfc, 0x58, 0x22, 0xba,
+	0x0a, 0x11, 0xf8, 0x67, 0x33, 0xe7, 0x57, 0xb5, 0xd0, 0xb5, 0xd3, 0x2d, 0x4f, 0x0f, 0xce, 0x66,
+	0xe4, 0x08, 0x7a, 0x29, 0xe5, 0x82, 0x86, 0x7e, 0x89, 0x71, 0x41, 0xcd, 0x96, 0xad, 0xf5, 0xbb,
+	0xee, 0xce, 0x50, 0x8e, 0x36, 0x94, 0x13, 0x0c, 0xd5, 0xb5, 0xd7, 0x55, 0xe7, 0xa5, 0x44, 0x11,
+	0x17, 0xba, 0x51, 0x2a, 0x8e, 0x8f, 0x96, 0x4d, 0x6d, 0x5b, 0xeb, 0xeb, 0xe3, 0x9d, 0x9f, 0xd5,
+	0x42, 0xef, 0x61, 0x11, 0x46, 0x62, 0x90, 0x16, 0x49, 0x40, 0x73, 0x0f, 0x6a, 0x94, 0xea, 0x39,
+	0x80, 0xed, 0x62, 0xa3, 0x89, 0x9b, 0x1d, 0x5b, 0xef, 0x1b, 0x5e, 0xaf, 0x58, 0x63, 0x38, 0x39,
+	0x87, 0x76, 0x86, 0x9c, 0xdf, 0xb2, 0x3c, 0x34, 0xa1, 0x1e, 0x70, 0xf4, 0xbd, 0x5a, 0xe8, 0x9d,
+	0x6b, 0xce, 0xd2, 0x81, 0x9c, 0xe1, 0x47, 0xb5, 0xd0, 0xf7, 0xad, 0x16, 0x17, 0x2c, 0xc7, 0x09,
+	0x85, 0x5d, 0x78, 0x10, 0x06, 0x64, 0xfb, 0xbe, 0xc7, 0x9f, 0x22, 0x9f, 0x7a, 0x2b, 0x0a, 0x52,
+	0x69, 0x00, 0x5c, 0xe4, 0xc5, 0x95, 0x28, 0x72, 0x1a, 0x9a, 0xdd, 0x9a, 0x31, 0x94, 0x81, 0xec,
+	0xad, 0xf2, 0x5f, 0xdf, 0xaf, 0xdf, 0x40, 0xaa, 0xbc, 0x82, 0x47, 0x60, 0x48, 0x49, 0xa2, 0x7d,
+	0xb6, 0x3a, 0xab, 0x77, 0x80, 0xa7, 0x56, 0xfb, 0x1e, 0x07, 0xad, 0x25, 0x0b, 0xd9, 0x50, 0x21,
+	0x86, 0x34, 0xeb, 0x6d, 0x54, 0xac, 0xaf, 0x1a, 0x34, 0x55, 0x7c, 0xe4, 0x23, 0xf4, 0x7c, 0x19,
+	0x80, 0xbb, 0x8c, 0xee, 0xbf, 0x2b, 0xe1, 0xfe, 0x7b, 0x25, 0xdc, 0xbf, 0x56, 0xa2, 0x73, 0x89,
+	0xb1, 0x5b, 0x47, 0x67, 0xbd, 0x84, 0x87, 0xef, 0x52, 0x7a, 0xce, 0x72, 0xea, 0x5f, 0x28, 0x29,
+	0x02, 0x46, 0x89, 0xf1, 0xa1, 0x92, 0xf0, 0xea, 0xef, 0x13, 0x90, 0x42, 0x8d, 0xfa, 0x8d, 0xc6,
+	0xcf, 0xa1, 0xc9, 0x52, 0xea, 0xb3, 0x4f, 0xe4, 0x40, 0x56, 0xf7, 0x57, 0xf2, 0xb7, 0x91, 0x98,
+	0x5e, 0xd0, 0xdb, 0x0f, 0x38, 0xe1, 0x6b, 0x0f, 0xe3, 0x42, 0x82, 0xde, 0x92, 0xb1, 0xf2, 0xb2,
+	0xe1, 0xc2, 0x5e, 0x35, 0xde, 0xdd, 0x0d, 0x2f, 0x30, 0xa1, 0xf3, 0xf9, 0x86, 0xf5, 0x12, 0xe3,
+	0x28, 0x44, 0x41, 0x4f, 0x9c, 0x9c, 0xde, 0x14, 0x51, 0x4e, 0x43, 0x07, 0x94, 0x01, 0xc9, 0x67,
+	0x43, 0x53, 0xad, 0x8a, 0xf5, 0x04, 0x5a, 0x09, 0x9f, 0x64, 0x78, 0x35, 0x23, 0x5d, 0xc9, 0x53,
+	0x57, 0xe7, 0xf3, 0xa0, 0x59, 0xff, 0x50, 0x87, 0x7f, 0x06, 0x00, 0x91, 0x15, 0x1b, 0xa0, 0x79,
+	0x03, 0x00, 0x00,
+}
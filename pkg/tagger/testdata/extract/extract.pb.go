@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: extract.proto
+
+package test
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Extract contains fields with hand-edited tags in Go file.
+type Extract struct {
+	// plain is field without options.
+	Plain      string `protobuf:"bytes,1,opt,name=plain,proto3" json:"plain,omitempty" bson:"plain" yaml:"plain" doc:"plain is field without options."`
+	Renamed    string `protobuf:"bytes,2,opt,name=renamed,json=customName,proto3" json:"renamed,omitempty" bson:"renamed"`
+	Deprecated string `protobuf:"bytes,3,opt,name=deprecated,proto3" json:"deprecated,omitempty" bson:"dep"` // Deprecated: Do not use.
+	Annotated  string `protobuf:"bytes,4,opt,name=annotated,proto3" json:"annotated,omitempty" graphql:"annotated" bson:"annotated"`
+	Aggregate  string `protobuf:"bytes,5,opt,name=aggregate,proto3" json:"aggregate,omitempty" db:"other" bson:"aggregate"`
+	Tabbed     int64  `protobuf:"varint,6,opt,name=tabbed,proto3" json:"tabbed,string,omitempty" bson:"tabbed" msgpack:"6"`
+	Untouched  string `protobuf:"bytes,7,opt,name=untouched,proto3" json:"untouched,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//	*Extract_A
+	//	*Extract_B
+	Choice isExtract_Choice `protobuf_oneof:"choice" bson:"choice"`
+	// Types that are valid to be assigned to AnnotatedChoice:
+	//	*Extract_C
+	AnnotatedChoice      isExtract_AnnotatedChoice `protobuf_oneof:"annotated_choice" graphql:"annotatedChoice" bson:"annotated_choice"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Extract) Reset()         { *m = Extract{} }
+func (m *Extract) String() string { return proto.CompactTextString(m) }
+func (*Extract) ProtoMessage()    {}
+func (*Extract) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6facc52bf885d4, []int{0}
+}
+
+func (m *Extract) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Extract.Unmarshal(m, b)
+}
+func (m *Extract) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Extract.Marshal(b, m, deterministic)
+}
+func (m *Extract) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Extract.Merge(m, src)
+}
+func (m *Extract) XXX_Size() int {
+	return xxx_messageInfo_Extract.Size(m)
+}
+func (m *Extract) XXX_DiscardUnknown() {
+	xxx_messageInfo_Extract.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Extract proto.InternalMessageInfo
+
+func (m *Extract) GetPlain() string {
+	if m != nil {
+		return m.Plain
+	}
+	return ""
+}
+
+func (m *Extract) GetRenamed() string {
+	if m != nil {
+		return m.Renamed
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (m *Extract) GetDeprecated() string {
+	if m != nil {
+		return m.Deprecated
+	}
+	return ""
+}
+
+func (m *Extract) GetAnnotated() string {
+	if m != nil {
+		return m.Annotated
+	}
+	return ""
+}
+
+func (m *Extract) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+func (m *Extract) GetTabbed() int64 {
+	if m != nil {
+		return m.Tabbed
+	}
+	return 0
+}
+
+func (m *Extract) GetUntouched() string {
+	if m != nil {
+		return m.Untouched
+	}
+	return ""
+}
+
+type isExtract_Choice interface {
+	isExtract_Choice()
+}
+
+type Extract_A struct {
+	A string `protobuf:"bytes,8,opt,name=a,proto3,oneof" bson:"a" yaml:"a\"quoted\""`
+}
+
+type Extract_B struct {
+	B int32 `protobuf:"varint,9,opt,name=b,proto3,oneof"`
+}
+
+func (*Extract_A) isExtract_Choice() {}
+
+func (*Extract_B) isExtract_Choice() {}
+
+func (m *Extract) GetChoice() isExtract_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (m *Extract) GetA() string {
+	if x, ok := m.GetChoice().(*Extract_A); ok {
+		return x.A
+	}
+	return ""
+}
+
+func (m *Extract) GetB() int32 {
+	if x, ok := m.GetChoice().(*Extract_B); ok {
+		return x.B
+	}
+	return 0
+}
+
+type isExtract_AnnotatedChoice interface {
+	isExtract_AnnotatedChoice()
+}
+
+type Extract_C struct {
+	C string `protobuf:"bytes,10,opt,name=c,proto3,oneof"`
+}
+
+func (*Extract_C) isExtract_AnnotatedChoice() {}
+
+func (m *Extract) GetAnnotatedChoice() isExtract_AnnotatedChoice {
+	if m != nil {
+		return m.AnnotatedChoice
+	}
+	return nil
+}
+
+func (m *Extract) GetC() string {
+	if x, ok := m.GetAnnotatedChoice().(*Extract_C); ok {
+		return x.C
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Extract) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Extract_A)(nil),
+		(*Extract_B)(nil),
+		(*Extract_C)(nil),
+	}
+}
+
+type Extract_Nested struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"value"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Extract_Nested) Reset()         { *m = Extract_Nested{} }
+func (m *Extract_Nested) String() string { return proto.CompactTextString(m) }
+func (*Extract_Nested) ProtoMessage()    {}
+func (*Extract_Nested) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6facc52bf885d4, []int{0, 0}
+}
+
+func (m *Extract_Nested) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Extract_Nested.Unmarshal(m, b)
+}
+func (m *Extract_Nested) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Extract_Nested.Marshal(b, m, deterministic)
+}
+func (m *Extract_Nested) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Extract_Nested.Merge(m, src)
+}
+func (m *Extract_Nested) XXX_Size() int {
+	return xxx_messageInfo_Extract_Nested.Size(m)
+}
+func (m *Extract_Nested) XXX_DiscardUnknown() {
+	xxx_messageInfo_Extract_Nested.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Extract_Nested proto.InternalMessageInfo
+
+func (m *Extract_Nested) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Extract)(nil), "test.Extract")
+	proto.RegisterType((*Extract_Nested)(nil), "test.Extract.Nested")
+}
+
+func init() { proto.RegisterFile("extract.proto", fileDescriptor_ca6facc52bf885d4) }
+
+var fileDescriptor_ca6facc52bf885d4 = []byte{
+	// 319 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xc1, 0x4a, 0xeb, 0x40,
+	0x14, 0x86, 0xef, 0x34, 0x4d, 0xd2, 0x1c, 0xb8, 0x22, 0xd3, 0x22, 0x63, 0xd4, 0x52, 0xb2, 0xea,
+	0x4a, 0x41, 0xc1, 0x85, 0x2b, 0xa9, 0x08, 0xae, 0xba, 0xc8, 0x5e, 0xe4, 0x64, 0x72, 0x98, 0x16,
+	0xda, 0x4c, 0x9c, 0x4c, 0xc4, 0x07, 0xe8, 0x53, 0xb8, 0xe8, 0x03, 0xf8, 0x94, 0x32, 0x13, 0x6d,
+	0x04, 0x57, 0xc3, 0xff, 0x7d, 0xff, 0xc0, 0x9c, 0x33, 0xf0, 0x9f, 0xde, 0xad, 0x41, 0x69, 0x2f,
+	0x6b, 0xa3, 0xad, 0xe6, 0x43, 0x4b, 0x8d, 0x4d, 0xc7, 0x16, 0x95, 0x22, 0x73, 0xd5, 0x1d, 0x9d,
+	0xca, 0xf6, 0x01, 0xc4, 0x8f, 0x5d, 0x99, 0x4f, 0x20, 0xac, 0x37, 0xb8, 0xae, 0x04, 0x9b, 0xb1,
+	0x79, 0x92, 0x77, 0x81, 0x9f, 0x41, 0x6c, 0xa8, 0xc2, 0x2d, 0x95, 0x62, 0xe0, 0x39, 0xc8, 0xb6,
+	0xb1, 0x7a, 0xbb, 0xc4, 0x2d, 0xf1, 0x0c, 0xa0, 0xa4, 0xda, 0x90, 0x44, 0x4b, 0xa5, 0x08, 0x9c,
+	0x5f, 0x0c, 0x04, 0xcb, 0x7f, 0x51, 0x7e, 0x0b, 0x09, 0x56, 0x95, 0xb6, 0xbe, 0x32, 0xf4, 0x15,
+	0xf1, 0xb1, 0xdb, 0x07, 0x63, 0x65, 0xb0, 0x5e, 0xbd, 0x6e, 0xee, 0xb2, 0x83, 0xce, 0xf2, 0xbe,
+	0xca, 0xef, 0x21, 0x41, 0xa5, 0x0c, 0x29, 0xb4, 0x24, 0x42, 0x7f, 0x2f, 0xfb, 0xdc, 0xed, 0x83,
+	0x0b, 0xe0, 0x30, 0x28, 0x0b, 0xde, 0xdb, 0x94, 0x3d, 0xa7, 0x71, 0x63, 0xb5, 0x41, 0x45, 0x79,
+	0x8f, 0xf9, 0x09, 0x44, 0x16, 0x8b, 0x82, 0x4a, 0x11, 0xcd, 0xd8, 0x3c, 0xc8, 0xbf, 0x13, 0x3f,
+	0x87, 0xa4, 0xad, 0xac, 0x6e, 0xe5, 0x8a, 0x4a, 0x11, 0xfb, 0xa1, 0x7a, 0xc0, 0x8f, 0x80, 0xa1,
+	0x18, 0x39, 0xfa, 0xf4, 0x2f, 0x67, 0xe8, 0x72, 0x21, 0x92, 0x19, 0x9b, 0x87, 0x2e, 0x17, 0x2e,
+	0x4b, 0x01, 0xde, 0xb3, 0x9c, 0xc9, 0x74, 0x0a, 0xd1, 0x92, 0x1a, 0xf7, 0xe2, 0x09, 0x84, 0x6f,
+	0xb8, 0x69, 0xe9, 0x67, 0x81, 0x3e, 0x2c, 0x46, 0x10, 0xc9, 0x95, 0x5e, 0x4b, 0x5a, 0x5c, 0xc3,
+	0xf1, 0x61, 0xbc, 0x97, 0x8e, 0xf1, 0xa9, 0x5b, 0xc5, 0xe9, 0xdf, 0x55, 0x3c, 0x78, 0x9d, 0x15,
+	0x91, 0xff, 0xa7, 0x9b, 0xaf, 0x01, 0x00, 0xe4, 0x7c, 0xbe, 0x10, 0xd3, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: gogodata.proto
+
+package test
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Gogo contains fields with gogoproto options are changing Go names and XXX fields.
+type Gogo struct {
+	ValueAll string `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty"`
+	*Base    `protobuf:"bytes,2,opt,name=base,proto3,embedded=base" json:"base,omitempty"`
+	NotNull  Base `protobuf:"bytes,3,opt,name=not_null,json=notNull,proto3" json:"not_null"`
+	// size conflicts with Size method
+	Size_ string `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//	*Gogo_Alpha
+	Choice               isGogo_Choice `protobuf_oneof:"choice"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+}
+
+func (m *Gogo) Reset()         { *m = Gogo{} }
+func (m *Gogo) String() string { return proto.CompactTextString(m) }
+func (*Gogo) ProtoMessage()    {}
+func (*Gogo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_306fe96119f137a4, []int{0}
+}
+func (m *Gogo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Gogo.Unmarshal(m, b)
+}
+func (m *Gogo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Gogo.Marshal(b, m, deterministic)
+}
+func (m *Gogo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Gogo.Merge(m, src)
+}
+func (m *Gogo) XXX_Size() int {
+	return xxx_messageInfo_Gogo.Size(m)
+}
+func (m *Gogo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Gogo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Gogo proto.InternalMessageInfo
+
+type isGogo_Choice interface {
+	isGogo_Choice()
+}
+
+type Gogo_Alpha struct {
+	Alpha string `protobuf:"bytes,5,opt,name=a,proto3,oneof" json:"a,omitempty"`
+}
+
+func (*Gogo_Alpha) isGogo_Choice() {}
+
+func (m *Gogo) GetChoice() isGogo_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (m *Gogo) GetValueAll() string {
+	if m != nil {
+		return m.ValueAll
+	}
+	return ""
+}
+
+func (m *Gogo) GetNotNull() Base {
+	if m != nil {
+		return m.NotNull
+	}
+	return Base{}
+}
+
+func (m *Gogo) GetSize_() string {
+	if m != nil {
+		return m.Size_
+	}
+	return ""
+}
+
+func (m *Gogo) GetAlpha() string {
+	if x, ok := m.GetChoice().(*Gogo_Alpha); ok {
+		return x.Alpha
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Gogo) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Gogo_Alpha)(nil),
+	}
+}
+
+// Base is embedded message.
+type Base struct {
+	ID            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_sizecache int32  `json:"-"`
+}
+
+func (m *Base) Reset()         { *m = Base{} }
+func (m *Base) String() string { return proto.CompactTextString(m) }
+func (*Base) ProtoMessage()    {}
+func (*Base) Descriptor() ([]byte, []int) {
+	return fileDescriptor_306fe96119f137a4, []int{1}
+}
+func (m *Base) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Base.Unmarshal(m, b)
+}
+func (m *Base) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Base.Marshal(b, m, deterministic)
+}
+func (m *Base) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Base.Merge(m, src)
+}
+func (m *Base) XXX_Size() int {
+	return xxx_messageInfo_Base.Size(m)
+}
+func (m *Base) XXX_DiscardUnknown() {
+	xxx_messageInfo_Base.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Base proto.InternalMessageInfo
+
+func (m *Base) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Gogo)(nil), "test.Gogo")
+	proto.RegisterType((*Base)(nil), "test.Base")
+}
+
+func init() { proto.RegisterFile("gogodata.proto", fileDescriptor_306fe96119f137a4) }
+
+var fileDescriptor_306fe96119f137a4 = []byte{
+	// 336 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x3f, 0x4b, 0xc3, 0x40,
+	0x18, 0xc6, 0x93, 0xf4, 0x5a, 0xd3, 0x2b, 0x56, 0x39, 0xff, 0x85, 0x2a, 0xa6, 0x1c, 0x2a, 0x1d,
+	0xa4, 0x05, 0x3b, 0x28, 0x5d, 0xa4, 0x41, 0x50, 0x17, 0x87, 0x0c, 0x5d, 0xcb, 0xdb, 0xe6, 0x48,
+	0x03, 0x2f, 0xb9, 0xd2, 0x5c, 0x33, 0x38, 0xfb, 0x01, 0x3a, 0x8a, 0x83, 0x83, 0xb3, 0x1f, 0xc4,
+	0xd1, 0x4f, 0x90, 0xa1, 0x7e, 0x11, 0xb9, 0x9c, 0x82, 0xe0, 0x94, 0xf0, 0xbc, 0xbf, 0xdf, 0xf1,
+	0xf0, 0xd0, 0x66, 0x2c, 0x63, 0x19, 0x81, 0x82, 0xee, 0x7c, 0x21, 0x95, 0x64, 0x44, 0x89, 0x4c,
+	0xb5, 0x76, 0x75, 0x5a, 0x06, 0x3d, 0xfd, 0x67, 0x6e, 0xad, 0x1d, 0x05, 0x71, 0x2c, 0x16, 0x3d,
+	0xf3, 0x31, 0x21, 0x7f, 0x77, 0x28, 0xb9, 0x95, 0xb1, 0x64, 0x57, 0xb4, 0x9e, 0x03, 0x8e, 0xf3,
+	0x1c, 0x10, 0x3d, 0xbb, 0x6d, 0x77, 0xea, 0xc1, 0xe1, 0xba, 0xf0, 0xdd, 0x11, 0xe0, 0x52, 0x0c,
+	0x11, 0x5f, 0x9e, 0x5e, 0x2b, 0x74, 0x92, 0xc9, 0x74, 0xc0, 0x73, 0x40, 0x1e, 0xba, 0x39, 0xe0,
+	0x48, 0xc3, 0xec, 0x92, 0x92, 0x09, 0x64, 0xc2, 0x73, 0xda, 0x76, 0xa7, 0x71, 0x41, 0xbb, 0xba,
+	0x42, 0x37, 0x80, 0x4c, 0x04, 0x07, 0x9f, 0x85, 0x6f, 0x6b, 0xb1, 0x69, 0xc4, 0xf3, 0x24, 0xc5,
+	0x24, 0x15, 0x3c, 0x2c, 0x05, 0x76, 0x4d, 0xdd, 0x54, 0xaa, 0x71, 0xba, 0x44, 0xf4, 0x2a, 0xff,
+	0x64, 0xef, 0xa3, 0xf0, 0x2d, 0x2d, 0x6f, 0x19, 0xf9, 0x17, 0xe5, 0xe1, 0x46, 0x2a, 0xd5, 0xc3,
+	0x12, 0x91, 0x9d, 0x50, 0x92, 0x25, 0x8f, 0xc2, 0x23, 0x65, 0xdd, 0x6d, 0x0d, 0x37, 0x0c, 0xac,
+	0x63, 0x1e, 0x96, 0x57, 0x76, 0x46, 0x6d, 0xf0, 0xaa, 0x25, 0xb2, 0xbf, 0x2e, 0xfc, 0xea, 0x10,
+	0xe7, 0x33, 0xd0, 0xac, 0x6b, 0x58, 0xe0, 0x77, 0x56, 0x68, 0xc3, 0x80, 0xac, 0xde, 0x7c, 0x2b,
+	0x38, 0xa2, 0xb5, 0xe9, 0x4c, 0x26, 0x53, 0xc1, 0x98, 0x66, 0x36, 0x0d, 0x63, 0x22, 0xce, 0xfb,
+	0x94, 0xe8, 0x72, 0xec, 0x94, 0x3a, 0x49, 0xf4, 0x33, 0xd3, 0xde, 0xba, 0xf0, 0x9d, 0xfb, 0x9b,
+	0x3f, 0x03, 0x8d, 0x93, 0x88, 0x87, 0x4e, 0x12, 0x0d, 0xc8, 0xb3, 0x7e, 0x92, 0xac, 0xbe, 0x8e,
+	0xad, 0x49, 0xad, 0x1c, 0xbc, 0xff, 0x3d, 0x00, 0xaf, 0x55, 0x9a, 0xe3, 0xb3, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: protojson.proto
+
+package test
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Protojson contains fields are serialized by protojson differently from encoding/json.
+type Protojson struct {
+	ValVvall string `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty"`
+	Renamed  string `protobuf:"bytes,2,opt,name=renamed,json=customName,proto3" json:"renamed,omitempty"`
+	// 64-bit integers are serialized as strings
+	Int64Value    int64      `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty"`
+	Uint64Value   uint64     `protobuf:"varint,4,opt,name=uint64_value,json=uint64Value,proto3" json:"uint64_value,omitempty"`
+	Sint64Value   int64      `protobuf:"zigzag64,5,opt,name=sint64_value,json=sint64Value,proto3" json:"sint64_value,omitempty"`
+	Fixed64Value  uint64     `protobuf:"fixed64,6,opt,name=fixed64_value,json=fixed64Value,proto3" json:"fixed64_value,omitempty"`
+	Sfixed64Value int64      `protobuf:"fixed64,7,opt,name=sfixed64_value,json=sfixed64Value,proto3" json:"sfixed64_value,omitempty"`
+	Int32Value    int32      `protobuf:"varint,8,opt,name=int32_value,json=int32Value,proto3" json:"int32_value,omitempty"`
+	Int64Values   []int64    `protobuf:"varint,9,rep,packed,name=int64_values,json=int64Values,proto3" json:"int64_values,omitempty"`
+	NestedValue   *Protojson `protobuf:"bytes,10,opt,name=nested_value,json=nestedValue,proto3" json:"nested_value,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//	*Protojson_ChoiceInt64
+	//	*Protojson_ChoiceText
+	Choice               isProtojson_Choice `protobuf_oneof:"choice"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Protojson) Reset()         { *m = Protojson{} }
+func (m *Protojson) String() string { return proto.CompactTextString(m) }
+func (*Protojson) ProtoMessage()    {}
+func (*Protojson) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3515b7fed9b15b1e, []int{0}
+}
+
+func (m *Protojson) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Protojson.Unmarshal(m, b)
+}
+func (m *Protojson) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Protojson.Marshal(b, m, deterministic)
+}
+func (m *Protojson) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Protojson.Merge(m, src)
+}
+func (m *Protojson) XXX_Size() int {
+	return xxx_messageInfo_Protojson.Size(m)
+}
+func (m *Protojson) XXX_DiscardUnknown() {
+	xxx_messageInfo_Protojson.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Protojson proto.InternalMessageInfo
+
+func (m *Protojson) GetValVvall() string {
+	if m != nil {
+		return m.ValVvall
+	}
+	return ""
+}
+
+func (m *Protojson) GetRenamed() string {
+	if m != nil {
+		return m.Renamed
+	}
+	return ""
+}
+
+func (m *Protojson) GetInt64Value() int64 {
+	if m != nil {
+		return m.Int64Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetUint64Value() uint64 {
+	if m != nil {
+		return m.Uint64Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetSint64Value() int64 {
+	if m != nil {
+		return m.Sint64Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetFixed64Value() uint64 {
+	if m != nil {
+		return m.Fixed64Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetSfixed64Value() int64 {
+	if m != nil {
+		return m.Sfixed64Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetInt32Value() int32 {
+	if m != nil {
+		return m.Int32Value
+	}
+	return 0
+}
+
+func (m *Protojson) GetInt64Values() []int64 {
+	if m != nil {
+		return m.Int64Values
+	}
+	return nil
+}
+
+func (m *Protojson) GetNestedValue() *Protojson {
+	if m != nil {
+		return m.NestedValue
+	}
+	return nil
+}
+
+type isProtojson_Choice interface {
+	isProtojson_Choice()
+}
+
+type Protojson_ChoiceInt64 struct {
+	ChoiceInt64 int64 `protobuf:"varint,11,opt,name=choice_int64,json=choiceInt64,proto3,oneof"`
+}
+
+type Protojson_ChoiceText struct {
+	ChoiceText string `protobuf:"bytes,12,opt,name=choice_text,json=text,proto3,oneof"`
+}
+
+func (*Protojson_ChoiceInt64) isProtojson_Choice() {}
+
+func (*Protojson_ChoiceText) isProtojson_Choice() {}
+
+func (m *Protojson) GetChoice() isProtojson_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (m *Protojson) GetChoiceInt64() int64 {
+	if x, ok := m.GetChoice().(*Protojson_ChoiceInt64); ok {
+		return x.ChoiceInt64
+	}
+	return 0
+}
+
+func (m *Protojson) GetChoiceText() string {
+	if x, ok := m.GetChoice().(*Protojson_ChoiceText); ok {
+		return x.ChoiceText
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Protojson) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Protojson_ChoiceInt64)(nil),
+		(*Protojson_ChoiceText)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*Protojson)(nil), "test.Protojson")
+}
+
+func init() { proto.RegisterFile("protojson.proto", fileDescriptor_3515b7fed9b15b1e) }
+
+var fileDescriptor_3515b7fed9b15b1e = []byte{
+	// 294 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xbf, 0x4e, 0xc3, 0x30,
+	0x18, 0xc4, 0x6b, 0x92, 0xa6, 0xcd, 0xe7, 0x94, 0x22, 0x4f, 0x96, 0x3a, 0x60, 0x40, 0x48, 0x9e,
+	0x3a, 0x24, 0x88, 0x07, 0x60, 0x2a, 0x0b, 0x42, 0x1e, 0xba, 0x46, 0x26, 0x31, 0x22, 0x28, 0x7f,
+	0x50, 0xed, 0x44, 0x7d, 0x38, 0x1e, 0x0e, 0xd9, 0x49, 0x43, 0xb2, 0xe5, 0xee, 0x7e, 0xfa, 0x74,
+	0x39, 0xc3, 0xf6, 0xe7, 0xd4, 0x98, 0xe6, 0x5b, 0x37, 0xf5, 0xde, 0x7d, 0x11, 0xdf, 0x28, 0x6d,
+	0xee, 0x7f, 0x3d, 0x08, 0xdf, 0x2f, 0x09, 0xd9, 0x41, 0xd8, 0xc9, 0x32, 0xed, 0x3a, 0x59, 0x96,
+	0x14, 0x31, 0xc4, 0x43, 0xb1, 0xee, 0x64, 0x79, 0xb4, 0x9a, 0xec, 0x60, 0x75, 0x52, 0xb5, 0xac,
+	0x54, 0x4e, 0xaf, 0x5c, 0x04, 0x59, 0xab, 0x4d, 0x53, 0xbd, 0xc9, 0x4a, 0x91, 0x5b, 0xc0, 0x45,
+	0x6d, 0x9e, 0x9f, 0xd2, 0x4e, 0x96, 0xad, 0xa2, 0x1e, 0x43, 0xdc, 0x13, 0xe0, 0xac, 0xa3, 0x75,
+	0xc8, 0x1d, 0x44, 0xed, 0x94, 0xf0, 0x19, 0xe2, 0xbe, 0xc0, 0xed, 0x1c, 0xd1, 0x53, 0x64, 0xc9,
+	0x10, 0x27, 0x02, 0xeb, 0x09, 0xf2, 0x00, 0x9b, 0xcf, 0xe2, 0xac, 0xf2, 0x91, 0x09, 0x18, 0xe2,
+	0x81, 0x88, 0x06, 0xb3, 0x87, 0x1e, 0xe1, 0x5a, 0xcf, 0xa9, 0x15, 0x43, 0xfc, 0x46, 0x6c, 0xf4,
+	0x0c, 0xeb, 0x2b, 0x27, 0xf1, 0xc0, 0xac, 0x19, 0xe2, 0x4b, 0x57, 0x39, 0x89, 0xc7, 0x3e, 0x93,
+	0x3a, 0x9a, 0x86, 0xcc, 0xe3, 0x9e, 0xc0, 0xff, 0x75, 0x34, 0x89, 0x21, 0xaa, 0x95, 0x36, 0x2a,
+	0x1f, 0x8e, 0x00, 0x43, 0x1c, 0xc7, 0xdb, 0xbd, 0xdd, 0x76, 0x3f, 0xee, 0x2a, 0x70, 0x0f, 0x5d,
+	0xfe, 0x21, 0xca, 0xbe, 0x9a, 0x22, 0x53, 0xa9, 0xbb, 0x44, 0xb1, 0xdd, 0xea, 0xb0, 0x10, 0xb8,
+	0x77, 0x5f, 0xad, 0x49, 0x76, 0x30, 0xc8, 0xd4, 0xa8, 0xb3, 0xa1, 0x91, 0x1d, 0xfc, 0xb0, 0x10,
+	0xbe, 0x55, 0x2f, 0x6b, 0x08, 0xfa, 0xf0, 0x23, 0x70, 0x6f, 0x99, 0xfc, 0x0d, 0x00, 0x6a, 0x69,
+	0x63, 0xd2, 0xde, 0x01, 0x00, 0x00,
+}
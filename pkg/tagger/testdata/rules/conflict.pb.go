@@ -0,0 +1,80 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: conflict.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Conflict has structured tag conflicting with (tagger.tags) one.
+type Conflict struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Conflict) Reset()         { *m = Conflict{} }
+func (m *Conflict) String() string { return proto.CompactTextString(m) }
+func (*Conflict) ProtoMessage()    {}
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_75785a042883e29b, []int{0}
+}
+
+func (m *Conflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Conflict.Unmarshal(m, b)
+}
+func (m *Conflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Conflict.Marshal(b, m, deterministic)
+}
+func (m *Conflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Conflict.Merge(m, src)
+}
+func (m *Conflict) XXX_Size() int {
+	return xxx_messageInfo_Conflict.Size(m)
+}
+func (m *Conflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_Conflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Conflict proto.InternalMessageInfo
+
+func (m *Conflict) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Conflict)(nil), "rules.Conflict")
+}
+
+func init() { proto.RegisterFile("conflict.proto", fileDescriptor_75785a042883e29b) }
+
+var fileDescriptor_75785a042883e29b = []byte{
+	// 121 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0xce, 0xcf, 0x4b,
+	0xcb, 0xc9, 0x4c, 0x2e, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0x2a, 0xcd, 0x49,
+	0x2d, 0x96, 0x12, 0x2e, 0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x10, 0x39, 0x25, 0x77,
+	0x2e, 0x0e, 0x67, 0xa8, 0x6a, 0x21, 0x6b, 0x2e, 0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x46,
+	0x05, 0x46, 0x0d, 0x4e, 0x27, 0xd5, 0x59, 0x2d, 0xf3, 0x98, 0x79, 0x92, 0x8a, 0xf3, 0xf3, 0xac,
+	0x94, 0xc0, 0xe2, 0x4a, 0xab, 0x5a, 0xe6, 0x31, 0xf3, 0x73, 0xf1, 0x72, 0xb1, 0x80, 0x04, 0x85,
+	0x58, 0xf3, 0x4b, 0x32, 0x52, 0x8b, 0x82, 0x20, 0x7a, 0x92, 0xd8, 0xc0, 0xe6, 0x19, 0x03, 0x06,
+	0x00, 0x1b, 0x1e, 0xb7, 0xac, 0x7d, 0x00, 0x00, 0x00,
+}
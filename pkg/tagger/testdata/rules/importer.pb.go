@@ -0,0 +1,90 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: importer.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Importer uses tag sets of imported file.
+type Importer struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Sets                 *Sets    `protobuf:"bytes,2,opt,name=sets,proto3" json:"sets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Importer) Reset()         { *m = Importer{} }
+func (m *Importer) String() string { return proto.CompactTextString(m) }
+func (*Importer) ProtoMessage()    {}
+func (*Importer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_394990ff89e0d02b, []int{0}
+}
+
+func (m *Importer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Importer.Unmarshal(m, b)
+}
+func (m *Importer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Importer.Marshal(b, m, deterministic)
+}
+func (m *Importer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Importer.Merge(m, src)
+}
+func (m *Importer) XXX_Size() int {
+	return xxx_messageInfo_Importer.Size(m)
+}
+func (m *Importer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Importer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Importer proto.InternalMessageInfo
+
+func (m *Importer) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Importer) GetSets() *Sets {
+	if m != nil {
+		return m.Sets
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Importer)(nil), "rules.Importer")
+}
+
+func init() { proto.RegisterFile("importer.proto", fileDescriptor_394990ff89e0d02b) }
+
+var fileDescriptor_394990ff89e0d02b = []byte{
+	// 147 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcb, 0xcc, 0x2d, 0xc8,
+	0x2f, 0x2a, 0x49, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0x2a, 0xcd, 0x49,
+	0x2d, 0x96, 0x12, 0x2e, 0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x10, 0x39, 0x29, 0xae,
+	0xe2, 0xd4, 0x92, 0x62, 0x08, 0x5b, 0x29, 0x86, 0x8b, 0xc3, 0x13, 0xaa, 0x53, 0x48, 0x89, 0x8b,
+	0xb5, 0x2c, 0x31, 0xa7, 0x34, 0x55, 0x82, 0x51, 0x81, 0x51, 0x83, 0xd3, 0x89, 0x67, 0x53, 0xcb,
+	0x3c, 0x66, 0xf6, 0xe2, 0x92, 0xfc, 0xa2, 0xc4, 0xf4, 0xd4, 0x20, 0x88, 0x94, 0x90, 0x3c, 0x17,
+	0x0b, 0x48, 0xb7, 0x04, 0x93, 0x02, 0xa3, 0x06, 0xb7, 0x11, 0xb7, 0x1e, 0xd8, 0x1a, 0xbd, 0xe0,
+	0xd4, 0x92, 0xe2, 0x20, 0xb0, 0x84, 0x15, 0xf7, 0xac, 0x96, 0x79, 0xcc, 0x6c, 0xc9, 0xf9, 0xb9,
+	0xb9, 0xf9, 0x79, 0x49, 0x6c, 0x60, 0x4b, 0x8c, 0x01, 0x03, 0x00, 0xef, 0xa6, 0x4c, 0x0d, 0x9e,
+	0x00, 0x00, 0x00,
+}
@@ -0,0 +1,90 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: legacy.proto
+
+package legacy
+
+import (
+	dep "dep"
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Legacy is message of file with package name only go_package.
+type Legacy struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Dep                  *dep.Dep `protobuf:"bytes,2,opt,name=dep,proto3" json:"dep,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Legacy) Reset()         { *m = Legacy{} }
+func (m *Legacy) String() string { return proto.CompactTextString(m) }
+func (*Legacy) ProtoMessage()    {}
+func (*Legacy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4b5c555c498591f0, []int{0}
+}
+
+func (m *Legacy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Legacy.Unmarshal(m, b)
+}
+func (m *Legacy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Legacy.Marshal(b, m, deterministic)
+}
+func (m *Legacy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Legacy.Merge(m, src)
+}
+func (m *Legacy) XXX_Size() int {
+	return xxx_messageInfo_Legacy.Size(m)
+}
+func (m *Legacy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Legacy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Legacy proto.InternalMessageInfo
+
+func (m *Legacy) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Legacy) GetDep() *dep.Dep {
+	if m != nil {
+		return m.Dep
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Legacy)(nil), "legacy.Legacy")
+}
+
+func init() { proto.RegisterFile("legacy.proto", fileDescriptor_4b5c555c498591f0) }
+
+var fileDescriptor_4b5c555c498591f0 = []byte{
+	// 135 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xc9, 0x49, 0x4d, 0x4f,
+	0x4c, 0xae, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0xa4, 0x84, 0x4b, 0x12,
+	0xd3, 0xd3, 0x53, 0x8b, 0xf4, 0x21, 0x14, 0x44, 0x52, 0x4a, 0x00, 0x22, 0x19, 0x9f, 0x92, 0x5a,
+	0x00, 0x11, 0x51, 0xf2, 0xe4, 0x62, 0xf3, 0x01, 0x8b, 0x09, 0x29, 0x73, 0xb1, 0x96, 0x25, 0xe6,
+	0x94, 0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x3a, 0xf1, 0xce, 0x6a, 0x99, 0xc7, 0xcc, 0x91,
+	0x54, 0x9c, 0x9f, 0x67, 0xa5, 0x54, 0xa6, 0x14, 0x04, 0x91, 0x13, 0x92, 0xe2, 0x62, 0x4e, 0x49,
+	0x2d, 0x90, 0x60, 0x52, 0x60, 0xd4, 0xe0, 0x36, 0xe2, 0xd0, 0x03, 0x99, 0xe3, 0x92, 0x5a, 0x10,
+	0x04, 0x12, 0x74, 0xe2, 0x88, 0x82, 0xda, 0x9d, 0xc4, 0x06, 0x36, 0xdb, 0x18, 0x30, 0x00, 0x79,
+	0x33, 0xb4, 0xdc, 0x9a, 0x00, 0x00, 0x00,
+}
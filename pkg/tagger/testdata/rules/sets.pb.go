@@ -0,0 +1,83 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: sets.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Sets is message of the file defines tag sets.
+type Sets struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Sets) Reset()         { *m = Sets{} }
+func (m *Sets) String() string { return proto.CompactTextString(m) }
+func (*Sets) ProtoMessage()    {}
+func (*Sets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7998cb46d4c4572a, []int{0}
+}
+
+func (m *Sets) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sets.Unmarshal(m, b)
+}
+func (m *Sets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sets.Marshal(b, m, deterministic)
+}
+func (m *Sets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sets.Merge(m, src)
+}
+func (m *Sets) XXX_Size() int {
+	return xxx_messageInfo_Sets.Size(m)
+}
+func (m *Sets) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sets proto.InternalMessageInfo
+
+func (m *Sets) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Sets)(nil), "rules.Sets")
+}
+
+func init() { proto.RegisterFile("sets.proto", fileDescriptor_7998cb46d4c4572a) }
+
+var fileDescriptor_7998cb46d4c4572a = []byte{
+	// 166 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0x4e, 0x2d, 0x29,
+	0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0x2a, 0xcd, 0x49, 0x2d, 0x96, 0x12, 0x2e,
+	0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x10, 0x39, 0x25, 0x43, 0x2e, 0x96, 0xe0, 0xd4,
+	0x92, 0x62, 0x21, 0x4d, 0x2e, 0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d,
+	0x4e, 0x27, 0xe1, 0x4d, 0x2d, 0xf3, 0x98, 0xf9, 0x92, 0xf3, 0x73, 0x73, 0xf3, 0xf3, 0x74, 0x8a,
+	0x4b, 0xf2, 0x8b, 0x12, 0xd3, 0x53, 0x83, 0x20, 0x2a, 0x9c, 0x82, 0x67, 0xb5, 0xcc, 0x63, 0x56,
+	0xe3, 0x62, 0x83, 0xc8, 0x0a, 0xc9, 0xa4, 0x17, 0x25, 0x16, 0x64, 0x14, 0xe6, 0x58, 0x29, 0x55,
+	0x57, 0xeb, 0xf9, 0x25, 0xe6, 0xa6, 0xd6, 0xd6, 0xea, 0xe4, 0x17, 0x94, 0x64, 0xe6, 0xe7, 0x25,
+	0xe6, 0x28, 0x81, 0x94, 0x2a, 0x73, 0xb1, 0x43, 0x4d, 0x90, 0xe2, 0xe7, 0x62, 0x4a, 0x49, 0x12,
+	0xe2, 0x84, 0x2b, 0xd4, 0x82, 0xc9, 0x24, 0xb1, 0x81, 0x9d, 0x63, 0x0c, 0x18, 0x00, 0x49, 0x68,
+	0x65, 0xb9, 0xb8, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: skip.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Public gets automatic tags.
+type Public struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Public) Reset()         { *m = Public{} }
+func (m *Public) String() string { return proto.CompactTextString(m) }
+func (*Public) ProtoMessage()    {}
+func (*Public) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d80db70c95db0c, []int{0}
+}
+
+func (m *Public) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Public.Unmarshal(m, b)
+}
+func (m *Public) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Public.Marshal(b, m, deterministic)
+}
+func (m *Public) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Public.Merge(m, src)
+}
+func (m *Public) XXX_Size() int {
+	return xxx_messageInfo_Public.Size(m)
+}
+func (m *Public) XXX_DiscardUnknown() {
+	xxx_messageInfo_Public.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Public proto.InternalMessageInfo
+
+func (m *Public) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// InternalState is skipped by 'skip' parameter patterns.
+type InternalState struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Counter              int64    `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InternalState) Reset()         { *m = InternalState{} }
+func (m *InternalState) String() string { return proto.CompactTextString(m) }
+func (*InternalState) ProtoMessage()    {}
+func (*InternalState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d80db70c95db0c, []int{1}
+}
+
+func (m *InternalState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InternalState.Unmarshal(m, b)
+}
+func (m *InternalState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InternalState.Marshal(b, m, deterministic)
+}
+func (m *InternalState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InternalState.Merge(m, src)
+}
+func (m *InternalState) XXX_Size() int {
+	return xxx_messageInfo_InternalState.Size(m)
+}
+func (m *InternalState) XXX_DiscardUnknown() {
+	xxx_messageInfo_InternalState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InternalState proto.InternalMessageInfo
+
+func (m *InternalState) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *InternalState) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+// Opaque skips automatic tags for every field.
+type Opaque struct {
+	Value   string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Counter int64  `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//	*Opaque_Text
+	Choice               isOpaque_Choice `protobuf_oneof:"choice"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Opaque) Reset()         { *m = Opaque{} }
+func (m *Opaque) String() string { return proto.CompactTextString(m) }
+func (*Opaque) ProtoMessage()    {}
+func (*Opaque) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86d80db70c95db0c, []int{2}
+}
+
+func (m *Opaque) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Opaque.Unmarshal(m, b)
+}
+func (m *Opaque) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Opaque.Marshal(b, m, deterministic)
+}
+func (m *Opaque) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Opaque.Merge(m, src)
+}
+func (m *Opaque) XXX_Size() int {
+	return xxx_messageInfo_Opaque.Size(m)
+}
+func (m *Opaque) XXX_DiscardUnknown() {
+	xxx_messageInfo_Opaque.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Opaque proto.InternalMessageInfo
+
+func (m *Opaque) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Opaque) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+type isOpaque_Choice interface {
+	isOpaque_Choice()
+}
+
+type Opaque_Text struct {
+	Text string `protobuf:"bytes,3,opt,name=text,proto3,oneof"`
+}
+
+func (*Opaque_Text) isOpaque_Choice() {}
+
+func (m *Opaque) GetChoice() isOpaque_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (m *Opaque) GetText() string {
+	if x, ok := m.GetChoice().(*Opaque_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Opaque) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Opaque_Text)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*Public)(nil), "rules.Public")
+	proto.RegisterType((*InternalState)(nil), "rules.InternalState")
+	proto.RegisterType((*Opaque)(nil), "rules.Opaque")
+}
+
+func init() { proto.RegisterFile("skip.proto", fileDescriptor_86d80db70c95db0c) }
+
+var fileDescriptor_86d80db70c95db0c = []byte{
+	// 208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0xce, 0xce, 0x2c,
+	0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0x2a, 0xcd, 0x49, 0x2d, 0x96, 0x12, 0x2e,
+	0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x10, 0x39, 0x25, 0x0b, 0x2e, 0xb6, 0x80, 0xd2,
+	0xa4, 0x9c, 0xcc, 0x64, 0x21, 0x3d, 0x2e, 0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x46, 0x05,
+	0x46, 0x0d, 0x4e, 0x27, 0x89, 0x59, 0x2d, 0xf3, 0x98, 0x85, 0x93, 0x8a, 0xf3, 0xf3, 0xac, 0x94,
+	0x0a, 0xc0, 0x2a, 0xe2, 0xc1, 0xd2, 0x4a, 0x41, 0x10, 0x65, 0x4a, 0xd1, 0x5c, 0xbc, 0x9e, 0x79,
+	0x25, 0xa9, 0x45, 0x79, 0x89, 0x39, 0xc1, 0x25, 0x89, 0x25, 0xa9, 0x42, 0x06, 0xa8, 0x06, 0x48,
+	0x81, 0x0c, 0x10, 0x85, 0x18, 0x90, 0x09, 0x55, 0x88, 0x6a, 0x84, 0x90, 0x04, 0x17, 0x7b, 0x72,
+	0x7e, 0x29, 0x48, 0x4e, 0x82, 0x49, 0x81, 0x51, 0x83, 0x39, 0x08, 0xc6, 0x55, 0xaa, 0xe4, 0x62,
+	0xf3, 0x2f, 0x48, 0x2c, 0x2c, 0x4d, 0xc5, 0xe7, 0xac, 0x7c, 0xb0, 0x0a, 0x62, 0xcd, 0x14, 0x12,
+	0xe1, 0x62, 0x29, 0x49, 0xad, 0x28, 0x91, 0x60, 0x06, 0x19, 0xe4, 0xc1, 0x10, 0x04, 0xe6, 0x59,
+	0xb1, 0x2e, 0x68, 0x99, 0xc7, 0xcc, 0xe8, 0xc4, 0xc1, 0xc5, 0x96, 0x9c, 0x91, 0x9f, 0x99, 0x9c,
+	0x9a, 0xc4, 0x06, 0x0e, 0x18, 0x63, 0xc0, 0x00, 0xdc, 0x49, 0xbd, 0x6a, 0x42, 0x01, 0x00, 0x00,
+}
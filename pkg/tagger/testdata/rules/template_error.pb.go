@@ -0,0 +1,81 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: template_error.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// TemplateError uses tag set with unknown template variable.
+type TemplateError struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TemplateError) Reset()         { *m = TemplateError{} }
+func (m *TemplateError) String() string { return proto.CompactTextString(m) }
+func (*TemplateError) ProtoMessage()    {}
+func (*TemplateError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f3ac6b05412be67b, []int{0}
+}
+
+func (m *TemplateError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateError.Unmarshal(m, b)
+}
+func (m *TemplateError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateError.Marshal(b, m, deterministic)
+}
+func (m *TemplateError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateError.Merge(m, src)
+}
+func (m *TemplateError) XXX_Size() int {
+	return xxx_messageInfo_TemplateError.Size(m)
+}
+func (m *TemplateError) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemplateError proto.InternalMessageInfo
+
+func (m *TemplateError) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*TemplateError)(nil), "rules.TemplateError")
+}
+
+func init() { proto.RegisterFile("template_error.proto", fileDescriptor_f3ac6b05412be67b) }
+
+var fileDescriptor_f3ac6b05412be67b = []byte{
+	// 135 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x29, 0x49, 0xcd, 0x2d,
+	0xc8, 0x49, 0x2c, 0x49, 0x8d, 0x4f, 0x2d, 0x2a, 0xca, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9,
+	0x17, 0x62, 0x2d, 0x2a, 0xcd, 0x49, 0x2d, 0x96, 0x12, 0x2e, 0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2,
+	0x87, 0x50, 0x10, 0x39, 0x25, 0x7d, 0x2e, 0xde, 0x10, 0xa8, 0x1e, 0x57, 0x90, 0x16, 0x21, 0x39,
+	0x2e, 0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x4e, 0x27, 0x8e, 0x4d,
+	0x2d, 0xf3, 0x98, 0x99, 0x93, 0x12, 0x53, 0x82, 0x20, 0xc2, 0x4e, 0xb2, 0xb3, 0x5a, 0xe6, 0x31,
+	0x4b, 0x48, 0x09, 0x0a, 0x71, 0x57, 0x57, 0xeb, 0x39, 0xe7, 0xe7, 0x94, 0xe6, 0xe6, 0xd5, 0xd6,
+	0x72, 0x31, 0xa5, 0x24, 0x71, 0x81, 0x54, 0x25, 0xb1, 0x81, 0x8d, 0x35, 0x06, 0x0c, 0x00, 0x17,
+	0x78, 0xfb, 0x9e, 0x8a, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,79 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: unknown_set.proto
+
+package rules
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// UnknownSet uses tag set is not defined.
+type UnknownSet struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnknownSet) Reset()         { *m = UnknownSet{} }
+func (m *UnknownSet) String() string { return proto.CompactTextString(m) }
+func (*UnknownSet) ProtoMessage()    {}
+func (*UnknownSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_90df20ea2937e827, []int{0}
+}
+
+func (m *UnknownSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnknownSet.Unmarshal(m, b)
+}
+func (m *UnknownSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnknownSet.Marshal(b, m, deterministic)
+}
+func (m *UnknownSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnknownSet.Merge(m, src)
+}
+func (m *UnknownSet) XXX_Size() int {
+	return xxx_messageInfo_UnknownSet.Size(m)
+}
+func (m *UnknownSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnknownSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnknownSet proto.InternalMessageInfo
+
+func (m *UnknownSet) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*UnknownSet)(nil), "rules.UnknownSet")
+}
+
+func init() { proto.RegisterFile("unknown_set.proto", fileDescriptor_90df20ea2937e827) }
+
+var fileDescriptor_90df20ea2937e827 = []byte{
+	// 107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2c, 0xcd, 0xcb, 0xce,
+	0xcb, 0x2f, 0xcf, 0x8b, 0x2f, 0x4e, 0x2d, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d,
+	0x2a, 0xcd, 0x49, 0x2d, 0x96, 0x12, 0x2e, 0x49, 0x4c, 0x4f, 0x4f, 0x2d, 0xd2, 0x87, 0x50, 0x10,
+	0x39, 0x25, 0x03, 0x2e, 0xae, 0x50, 0x88, 0x86, 0xe0, 0xd4, 0x12, 0x21, 0x25, 0x2e, 0xd6, 0xb2,
+	0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x4e, 0x27, 0x9e, 0x4d, 0x2d, 0xf3, 0x98,
+	0xd9, 0x73, 0x33, 0x8b, 0x8b, 0x33, 0xf3, 0xd2, 0x83, 0x20, 0x52, 0x49, 0x6c, 0x60, 0x8d, 0xc6,
+	0x80, 0x01, 0x00, 0xf7, 0x76, 0x93, 0xb1, 0x69, 0x00, 0x00, 0x00,
+}
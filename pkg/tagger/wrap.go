@@ -0,0 +1,129 @@
+package tagger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// runWrappedPlugin runs wrapped plugin (see 'wrap' parameter) with the same CodeGeneratorRequest
+// but with pass-through parameter (see 'wrap_param' parameter).
+// Response of the wrapped plugin becomes plugin response, so its Go files are updated in memory
+// and returned together with other files of the wrapped plugin.
+func (p *plugin) runWrappedPlugin() error {
+	req := proto.Clone(p.request).(*plugin_go.CodeGeneratorRequest)
+	req.Parameter = nil
+	if len(p.wrapParam) > 0 {
+		req.Parameter = proto.String(p.wrapParam)
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request of wrapped plugin: %s", err.Error())
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(p.wrap)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run wrapped plugin '%s': %s", p.wrap, err.Error())
+	}
+
+	resp := &plugin_go.CodeGeneratorResponse{}
+	if err = proto.Unmarshal(out.Bytes(), resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response of wrapped plugin '%s': %s", p.wrap, err.Error())
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("wrapped plugin '%s' failed: %s", p.wrap, resp.GetError())
+	}
+
+	// combined response supports features are supported by both plugins
+	resp.SupportedFeatures = proto.Uint64(resp.GetSupportedFeatures() & p.response.GetSupportedFeatures())
+	p.response = resp
+
+	return nil
+}
+
+// wrappedFileName returns name of Go file (provided by suffix) is generated by wrapped plugin for source proto file.
+// Wrapped plugin is expected to name Go files by protoc-gen-go rules:
+// - Go import path is provided by 'M<proto file>' mapping of wrap_param or by go_package file option
+// - Go file is put into Go import path folder ('paths=import', default) or into source proto file folder ('paths=source_relative')
+// - 'module' prefix is removed from Go file name
+// Example: Go file name of 'test/data.proto' with go_package "github.com/acme/api/test;test"
+// and wrap_param="module=github.com/acme/api" is 'test/data.pb.go'.
+func (p *plugin) wrappedFileName(f *descriptor.FileDescriptorProto, suffix string) (string, error) {
+	var paths, module string
+	importPath := f.GetOptions().GetGoPackage()
+	for _, prm := range strings.Split(p.wrapParam, ",") {
+		kv := strings.SplitN(prm, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "paths":
+			paths = kv[1]
+		case "module":
+			module = kv[1]
+		case "M" + f.GetName():
+			importPath = kv[1]
+		}
+	}
+
+	// Go package name is provided after ';' (e.g. "github.com/acme/api/test;test"),
+	// value without '/' is Go package name only
+	if i := strings.Index(importPath, ";"); i >= 0 {
+		importPath = importPath[:i]
+	} else if !strings.Contains(importPath, "/") {
+		importPath = ""
+	}
+
+	name := strings.TrimSuffix(f.GetName(), path.Ext(f.GetName())) + suffix
+	switch paths {
+	case "", "import":
+		if len(importPath) > 0 {
+			name = path.Join(importPath, path.Base(name))
+		}
+	case "source_relative":
+	default:
+		return "", fmt.Errorf("unsupported value '%s' of 'paths' parameter, must be one of: import, source_relative", paths)
+	}
+
+	if len(module) > 0 {
+		if !strings.HasPrefix(name, module+"/") {
+			return "", fmt.Errorf("Go file '%s' is not in module '%s'", name, module)
+		}
+		name = strings.TrimPrefix(name, module+"/")
+	}
+
+	return name, nil
+}
+
+// findWrappedFile returns Go file (provided by target file name) of wrapped plugin response.
+// Target files are named by full Go file names in response in wrap mode (see targetFileName func).
+// It returns nil if plugin doesn't wrap other plugin or optional Go file (e.g. target file of other plugin)
+// is not found in response. Missing protoc-gen-go Go file is reported as error.
+func (p *plugin) findWrappedFile(name string, optional bool) (*plugin_go.CodeGeneratorResponse_File, error) {
+	if len(p.wrap) == 0 {
+		return nil, nil
+	}
+
+	for _, f := range p.response.GetFile() {
+		if len(f.GetInsertionPoint()) == 0 && f.GetName() == name {
+			return f, nil
+		}
+	}
+
+	if optional {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("Go file '%s' is not found in response of wrapped plugin '%s'", name, p.wrap)
+}
@@ -0,0 +1,222 @@
+package tagger_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/taggertest"
+)
+
+// Test binary runs as wrapped plugin if the following environment variables are set:
+// it returns Go files (comma delimited wrappedFileEnv) with content of Go fixture (wrappedSourceEnv).
+const (
+	wrappedFileEnv   = "GOTAGGER_TEST_WRAPPED_FILE"
+	wrappedSourceEnv = "GOTAGGER_TEST_WRAPPED_SOURCE"
+)
+
+func TestMain(m *testing.M) {
+	if name := os.Getenv(wrappedFileEnv); len(name) > 0 {
+		runWrappedPlugin(name, os.Getenv(wrappedSourceEnv))
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runWrappedPlugin writes response of fake wrapped plugin to std output
+func runWrappedPlugin(name string, source string) {
+	resp := &plugin_go.CodeGeneratorResponse{}
+	if src, err := ioutil.ReadFile(source); err != nil {
+		resp.Error = proto.String(err.Error())
+	} else {
+		for _, n := range strings.Split(name, ",") {
+			resp.File = append(resp.File, &plugin_go.CodeGeneratorResponse_File{Name: proto.String(n), Content: proto.String(string(src))})
+		}
+	}
+
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		os.Exit(1)
+	}
+	if _, err = os.Stdout.Write(data); err != nil {
+		os.Exit(1)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+	// data.proto gets Go import path, so Go file names of import and source_relative paths are different
+	for i, f := range set.GetFile() {
+		if f.GetName() == "data.proto" {
+			f = proto.Clone(f).(*descriptor.FileDescriptorProto)
+			if f.Options == nil {
+				f.Options = &descriptor.FileOptions{}
+			}
+			f.Options.GoPackage = proto.String("github.com/acme/api/test;test")
+			set.File[i] = f
+		}
+	}
+
+	cases := []struct {
+		// file is Go file name the wrapped plugin returns
+		file string
+		taggertest.Case
+	}{
+		{
+			file: "github.com/acme/api/test/data.pb.go",
+			Case: taggertest.Case{Name: "import paths"},
+		},
+		{
+			file: "test/data.pb.go",
+			Case: taggertest.Case{Name: "module", Parameter: `wrap_param="module=github.com/acme/api"`},
+		},
+		{
+			file: "data.pb.go",
+			Case: taggertest.Case{Name: "source relative paths", Parameter: `wrap_param="paths=source_relative"`},
+		},
+		{
+			file: "example.com/data/data.pb.go",
+			Case: taggertest.Case{Name: "import path mapping", Parameter: `wrap_param="Mdata.proto=example.com/data"`},
+		},
+		{
+			// Go file with the same base name in other folder isn't Go file of data.proto
+			file: "other/data.pb.go",
+			Case: taggertest.Case{
+				Name:    "missing Go file",
+				WantErr: "Go file 'github.com/acme/api/test/data.pb.go' is not found in response of wrapped plugin",
+			},
+		},
+		{
+			file: "data.pb.go",
+			Case: taggertest.Case{
+				Name:      "module mismatch",
+				Parameter: `wrap_param="module=example.com/other"`,
+				WantErr:   "Go file 'github.com/acme/api/test/data.pb.go' is not in module 'example.com/other'",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			if err := os.Setenv(wrappedFileEnv, c.file); err != nil {
+				t.Fatal(err.Error())
+			}
+			defer os.Unsetenv(wrappedFileEnv)
+			if err := os.Setenv(wrappedSourceEnv, "testdata/data.pb.go"); err != nil {
+				t.Fatal(err.Error())
+			}
+			defer os.Unsetenv(wrappedSourceEnv)
+
+			c.Set = set
+			c.Generate = []string{"data.proto"}
+			c.Parameter = "wrap=" + exe + "," + c.Parameter
+			// Go files are not read from file system in wrap mode
+			c.Go = map[string]string{}
+			if len(c.WantErr) == 0 {
+				c.Want = map[string]map[string]string{
+					"Data": {
+						"ValVvall": `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty"`,
+					},
+				}
+			}
+			c.Run(t)
+		})
+	}
+}
+
+func TestWrapFileNames(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = os.Setenv(wrappedSourceEnv, "testdata/data.pb.go"); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.Unsetenv(wrappedSourceEnv)
+
+	set := taggertest.LoadDescriptorSet(t, "testdata/test.protoset")
+
+	t.Run("optional target is not read from file system", func(t *testing.T) {
+		if err := os.Setenv(wrappedFileEnv, "data.pb.go"); err != nil {
+			t.Fatal(err.Error())
+		}
+		defer os.Unsetenv(wrappedFileEnv)
+
+		dto := goFixture(t, "data.pb.dto.go")
+		files, err := taggertest.Process(&taggertest.Case{
+			Set:       set,
+			Generate:  []string{"data.proto"},
+			Parameter: "wrap=" + exe + `,wrap_param="paths=source_relative",targets=".pb.dto.go+{{.GoName}}DTO"`,
+			Go:        map[string]string{"data.pb.dto.go": dto},
+		})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if files["data.pb.dto.go"] != dto {
+			t.Error("target file is not found in response of wrapped plugin but it is updated")
+		}
+	})
+
+	t.Run("Go files with the same base name", func(t *testing.T) {
+		if err := os.Setenv(wrappedFileEnv, "test/data.pb.go,other/data.pb.go"); err != nil {
+			t.Fatal(err.Error())
+		}
+		defer os.Unsetenv(wrappedFileEnv)
+
+		other := withOtherData(set)
+		files, err := taggertest.Process(&taggertest.Case{
+			Set:       other,
+			Generate:  []string{"data.proto", "other/data.proto"},
+			Parameter: "wrap=" + exe + `,wrap_param="Mdata.proto=example.com/test,Mother/data.proto=example.com/other,module=example.com"`,
+			Go:        map[string]string{},
+		})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, name := range []string{"test/data.pb.go", "other/data.pb.go"} {
+			if !strings.Contains(files[name], `bson:"name12,omitempty"`) {
+				t.Errorf("Go file '%s' is not updated", name)
+			}
+		}
+	})
+}
+
+// withOtherData returns descriptor set (provided by set) with 'other/data.proto' file:
+// it is copy of data.proto in other folder and proto package, so its Go file has the same base name.
+func withOtherData(set *descriptor.FileDescriptorSet) *descriptor.FileDescriptorSet {
+	var rename func(messages []*descriptor.DescriptorProto)
+	rename = func(messages []*descriptor.DescriptorProto) {
+		for _, m := range messages {
+			for _, fd := range m.GetField() {
+				if strings.HasPrefix(fd.GetTypeName(), ".test.") {
+					fd.TypeName = proto.String(".other." + strings.TrimPrefix(fd.GetTypeName(), ".test."))
+				}
+			}
+			rename(m.GetNestedType())
+		}
+	}
+
+	other := &descriptor.FileDescriptorSet{File: set.GetFile()}
+	for _, f := range set.GetFile() {
+		if f.GetName() == "data.proto" {
+			f = proto.Clone(f).(*descriptor.FileDescriptorProto)
+			f.Name = proto.String("other/data.proto")
+			f.Package = proto.String("other")
+			rename(f.GetMessageType())
+			other.File = append(other.File, f)
+		}
+	}
+
+	return other
+}
@@ -0,0 +1,437 @@
+// Package taggertest runs gotagger plugin in memory to write table tests of struct tags.
+// Test case contains source proto files (FileDescriptorSet or inline descriptor protos),
+// 'gotagger_out' parameter value and Go source fixtures. It checks resulting struct tags per Go field
+// and compares updated Go files with golden files.
+// Golden files are updated by '-taggertest.update' test flag.
+// Example:
+//
+//	func TestTags(t *testing.T) {
+//		taggertest.Run(t, []taggertest.Case{
+//			{
+//				Name:      "original field names",
+//				Set:       taggertest.LoadDescriptorSet(t, "testdata/data.protoset"),
+//				Parameter: `original_field_names="bson"`,
+//				Go:        map[string]string{"data.pb.go": dataGo},
+//				Want:      map[string]map[string]string{"Data": {"ValVvall": `bson:"val_vvall"`}},
+//			},
+//		})
+//	}
+package taggertest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/amsokol/protoc-gen-gotagger/pkg/tagger"
+)
+
+// update is true if golden files should be updated instead of compared with updated Go files
+var update = flag.Bool("taggertest.update", false, "update golden files of gotagger test cases")
+
+// Case is test case of gotagger plugin
+type Case struct {
+	// Name is test case name (see testing.T.Run func)
+	Name string
+
+	// Set contains source proto files (e.g. produced by 'protoc --include_imports --descriptor_set_out').
+	Set *descriptor.FileDescriptorSet
+
+	// Files are inline source proto files in addition to Set ones.
+	// Imported proto files are not found in Set and Files are got from global proto registry
+	// (e.g. tagger/tagger.proto, google/protobuf/descriptor.proto).
+	Files []*descriptor.FileDescriptorProto
+
+	// Generate contains names of proto files to generate (CodeGeneratorRequest file_to_generate).
+	// Proto files of Set and Files are not imported by other ones are generated by default.
+	Generate []string
+
+	// Parameter contains 'gotagger_out' parameter value (e.g. original_field_names="bson,graphql")
+	Parameter string
+
+	// Go is map of <Go file name>-><Go source fixture> (e.g. "data.pb.go").
+	// Go files are read by plugin from in-memory file system.
+	Go map[string]string
+
+	// Providers are tag providers in addition to registered ones (see tagger.RegisterTagProvider func)
+	Providers []tagger.TagProvider
+
+	// Want is map of <Go struct name>-><Go field name>-><expected struct tags>.
+	// Empty string means field has no tags.
+	Want map[string]map[string]string
+
+	// WantErr is expected part of plugin error. Plugin must fail if it is set.
+	WantErr string
+
+	// Golden is folder with golden files of updated Go files.
+	// Golden file name is Go file name with '.golden' extension (e.g. data.pb.go.golden).
+	Golden string
+}
+
+// Run runs test cases as subtests
+func Run(t *testing.T, cases []Case) {
+	t.Helper()
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.Name, func(t *testing.T) {
+			c.Run(t)
+		})
+	}
+}
+
+// Run runs test case: it processes source proto files by plugin and checks struct tags and golden files
+func (c *Case) Run(t testing.TB) {
+	t.Helper()
+
+	files, err := Process(c)
+	if len(c.WantErr) > 0 {
+		if err == nil {
+			t.Fatalf("expected error containing '%s', got none", c.WantErr)
+		}
+		if !strings.Contains(err.Error(), c.WantErr) {
+			t.Fatalf("expected error containing '%s', got: %s", c.WantErr, err.Error())
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if c.Want != nil {
+		tags, err := Tags(files)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		checkTags(t, tags, c.Want)
+	}
+
+	if len(c.Golden) > 0 {
+		checkGolden(t, c.Golden, files)
+	}
+}
+
+// Process processes source proto files of test case by plugin (see tagger.Plugin.Proccess func) in memory.
+// It returns map of <Go file name>-><Go source> of every Go fixture (updated or not)
+// and Go files are returned by plugin.
+func Process(c *Case) (map[string]string, error) {
+	req, err := request(c)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request to binary data: %s", err.Error())
+	}
+
+	fsys := fstest.MapFS{}
+	for name, src := range c.Go {
+		fsys[name] = &fstest.MapFile{Data: []byte(src)}
+	}
+
+	var out bytes.Buffer
+	p := tagger.NewPlugin(bytes.NewReader(data), &out, tagger.WithTagProviders(c.Providers...), tagger.WithFS(fsys))
+	if err = p.Proccess(); err != nil {
+		return nil, fmt.Errorf("failed to process request: %s", err.Error())
+	}
+
+	resp := &plugin_go.CodeGeneratorResponse{}
+	if err = proto.Unmarshal(out.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response from binary data: %s", err.Error())
+	}
+	if len(resp.GetError()) > 0 {
+		return nil, fmt.Errorf("plugin error: %s", resp.GetError())
+	}
+
+	files := map[string]string{}
+	for name, src := range c.Go {
+		files[name] = src
+	}
+	for _, f := range resp.GetFile() {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	return files, nil
+}
+
+// Tags returns struct tags of Go files (provided by map of <Go file name>-><Go source>)
+// as map of <Go struct name>-><Go field name>-><struct tags>.
+// Embedded field name is equal to name of field type.
+func Tags(files map[string]string) (map[string]map[string]string, error) {
+	fset := token.NewFileSet()
+	tags := map[string]map[string]string{}
+
+	for name, src := range files {
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parse Go file '%s': %s", name, err.Error())
+		}
+
+		for _, d := range f.Decls {
+			g, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, s := range g.Specs {
+				tp, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				st, ok := tp.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				fields := map[string]string{}
+				for _, fld := range st.Fields.List {
+					var tag string
+					if fld.Tag != nil {
+						if tag, err = strconv.Unquote(fld.Tag.Value); err != nil {
+							return nil, fmt.Errorf("failed to unquote tags %s of struct '%s' in Go file '%s': %s",
+								fld.Tag.Value, tp.Name.String(), name, err.Error())
+						}
+					}
+					for _, n := range fieldNames(fld) {
+						fields[n] = tag
+					}
+				}
+				tags[tp.Name.String()] = fields
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// LoadDescriptorSet reads FileDescriptorSet from binary file
+// (e.g. produced by 'protoc --include_imports --descriptor_set_out').
+func LoadDescriptorSet(t testing.TB, path string) *descriptor.FileDescriptorSet {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read descriptor set file '%s': %s", path, err.Error())
+	}
+
+	set := &descriptor.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		t.Fatalf("failed to unmarshal descriptor set from file '%s': %s", path, err.Error())
+	}
+
+	return set
+}
+
+// request returns CodeGeneratorRequest of test case.
+// Proto files are sorted so dependencies go before proto files import them.
+func request(c *Case) (*plugin_go.CodeGeneratorRequest, error) {
+	var given []*descriptor.FileDescriptorProto
+	given = append(given, c.Set.GetFile()...)
+	given = append(given, c.Files...)
+
+	byName := map[string]*descriptor.FileDescriptorProto{}
+	imported := map[string]bool{}
+	for _, f := range given {
+		byName[f.GetName()] = f
+		for _, d := range f.GetDependency() {
+			imported[d] = true
+		}
+	}
+
+	req := &plugin_go.CodeGeneratorRequest{FileToGenerate: c.Generate}
+	if len(c.Parameter) > 0 {
+		req.Parameter = proto.String(c.Parameter)
+	}
+
+	added := map[string]bool{}
+	var add func(f *descriptor.FileDescriptorProto) error
+	add = func(f *descriptor.FileDescriptorProto) error {
+		if added[f.GetName()] {
+			return nil
+		}
+		added[f.GetName()] = true
+
+		for _, d := range f.GetDependency() {
+			dep, ok := byName[d]
+			if !ok {
+				var err error
+				if dep, err = registryFile(d); err != nil {
+					return err
+				}
+			}
+			if err := add(dep); err != nil {
+				return err
+			}
+		}
+
+		req.ProtoFile = append(req.ProtoFile, f)
+		return nil
+	}
+
+	for _, f := range given {
+		if err := add(f); err != nil {
+			return nil, err
+		}
+		if len(c.Generate) == 0 && !imported[f.GetName()] {
+			req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+		}
+	}
+
+	return req, nil
+}
+
+// registryFile returns imported proto file (provided by name) from global proto registry.
+// Proto files may be registered with other name if they were compiled with other proto path
+// (e.g. tagger/tagger.proto is registered as tagger.proto), so base name is tried also.
+func registryFile(name string) (*descriptor.FileDescriptorProto, error) {
+	d, err := protoregistry.GlobalFiles.FindFileByPath(name)
+	if err != nil {
+		if d, err = protoregistry.GlobalFiles.FindFileByPath(path.Base(name)); err != nil {
+			return nil, fmt.Errorf("failed to find imported proto file '%s': %s", name, err.Error())
+		}
+	}
+
+	f := protodesc.ToFileDescriptorProto(d)
+	f.Name = proto.String(name)
+	return f, nil
+}
+
+// fieldNames returns names of Go struct field declaration.
+// Embedded field has no name, so it returns name of field type (e.g. 'Data' for '*pkg.Data').
+func fieldNames(f *ast.Field) []string {
+	if len(f.Names) > 0 {
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.String())
+		}
+		return names
+	}
+
+	t := f.Type
+	if s, ok := t.(*ast.StarExpr); ok {
+		t = s.X
+	}
+	switch e := t.(type) {
+	case *ast.Ident:
+		return []string{e.Name}
+	case *ast.SelectorExpr:
+		return []string{e.Sel.Name}
+	}
+
+	return nil
+}
+
+// checkTags reports struct tags are not equal to expected ones
+func checkTags(t testing.TB, tags map[string]map[string]string, want map[string]map[string]string) {
+	t.Helper()
+
+	for _, sn := range sortedStructs(want) {
+		s, ok := tags[sn]
+		if !ok {
+			t.Errorf("struct '%s' is not found", sn)
+			continue
+		}
+		for _, fn := range sortedKeys(want[sn]) {
+			tag, ok := s[fn]
+			if !ok {
+				t.Errorf("field '%s' of struct '%s' is not found", fn, sn)
+				continue
+			}
+			if w := want[sn][fn]; tag != w {
+				t.Errorf("tags of field '%s' of struct '%s':\n\tgot:  `%s`\n\twant: `%s`", fn, sn, tag, w)
+			}
+		}
+	}
+}
+
+// checkGolden compares Go files with golden files (provided by folder) or updates golden files
+// if '-taggertest.update' flag is set
+func checkGolden(t testing.TB, folder string, files map[string]string) {
+	t.Helper()
+
+	for _, name := range sortedKeys(files) {
+		Golden(t, filepath.Join(folder, filepath.FromSlash(name)+".golden"), files[name])
+	}
+}
+
+// Golden compares content (e.g. Go file, patch) with golden file (provided by path)
+// or updates golden file if '-taggertest.update' flag is set
+func Golden(t testing.TB, golden string, content string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatalf("failed to create golden file folder '%s': %s", filepath.Dir(golden), err.Error())
+		}
+		if err := ioutil.WriteFile(golden, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write golden file '%s': %s", golden, err.Error())
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Errorf("failed to read golden file '%s' (run tests with -taggertest.update flag to create it): %s", golden, err.Error())
+		return
+	}
+	if content != string(want) {
+		t.Errorf("content is not equal to golden file '%s': %s", golden, firstDiff(content, string(want)))
+	}
+}
+
+// firstDiff returns description of the first different line of content and golden file
+func firstDiff(got, want string) string {
+	gl := strings.Split(got, "\n")
+	wl := strings.Split(want, "\n")
+	for i := 0; i < len(gl) || i < len(wl); i++ {
+		var g, w string
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if g != w || i >= len(gl) || i >= len(wl) {
+			return fmt.Sprintf("line %d:\n\tgot:  %s\n\twant: %s", i+1, g, w)
+		}
+	}
+	return "files are equal"
+}
+
+// sortedStructs returns sorted struct names of map <Go struct name>-><Go field name>->value
+func sortedStructs(m map[string]map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedKeys returns sorted keys of map
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
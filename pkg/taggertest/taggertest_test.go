@@ -0,0 +1,124 @@
+package taggertest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+)
+
+// recorder records errors of test case instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// inlineFile returns inline source proto file that imports tagger/tagger.proto from global proto registry
+func inlineFile() *descriptor.FileDescriptorProto {
+	opts := &descriptor.FieldOptions{}
+	if err := proto.SetExtension(opts, tagger.E_Tags, proto.String(`bson:"v"`)); err != nil {
+		panic(err)
+	}
+
+	return &descriptor.FileDescriptorProto{
+		Name:       proto.String("inline.proto"),
+		Package:    proto.String("inline"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"tagger/tagger.proto"},
+		MessageType: []*descriptor.DescriptorProto{{
+			Name: proto.String("Inline"),
+			Field: []*descriptor.FieldDescriptorProto{{
+				Name:     proto.String("value"),
+				JsonName: proto.String("value"),
+				Number:   proto.Int32(1),
+				Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options:  opts,
+			}},
+		}},
+	}
+}
+
+const inlineGo = `package inline
+
+type Base struct{}
+
+type Inline struct {
+	Value string ` + "`" + `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"` + "`" + `
+	*Base
+	Untagged int
+}
+`
+
+func TestRun(t *testing.T) {
+	Run(t, []Case{
+		{
+			Name:      "inline proto file",
+			Files:     []*descriptor.FileDescriptorProto{inlineFile()},
+			Parameter: "original_field_names=db",
+			Go:        map[string]string{"inline.pb.go": inlineGo},
+			Want: map[string]map[string]string{
+				"Inline": {
+					"Value": `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty" bson:"v" db:"value"`,
+					// embedded field is named by type name, field without tags has empty tags
+					"Base":     "",
+					"Untagged": "",
+				},
+			},
+		},
+		{
+			Name:      "plugin error",
+			Files:     []*descriptor.FileDescriptorProto{inlineFile()},
+			Parameter: "unknown=value",
+			Go:        map[string]string{"inline.pb.go": inlineGo},
+			WantErr:   "invalid parameter 'unknown' at position 1: unknown parameter",
+		},
+	})
+}
+
+func TestCheckTags(t *testing.T) {
+	r := &recorder{TB: t}
+	checkTags(r, map[string]map[string]string{"Inline": {"Value": `bson:"v"`}}, map[string]map[string]string{
+		"Inline":  {"Value": `bson:"w"`, "Other": ""},
+		"Missing": {"Value": ""},
+	})
+
+	want := []string{
+		"field 'Other' of struct 'Inline' is not found",
+		"tags of field 'Value' of struct 'Inline':\n\tgot:  `bson:\"v\"`\n\twant: `bson:\"w\"`",
+		"struct 'Missing' is not found",
+	}
+	if strings.Join(r.errors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected errors:\n%s", strings.Join(r.errors, "\n"))
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "folder", "file.golden")
+
+	*update = true
+	Golden(t, path, "a\nb\n")
+	*update = false
+
+	r := &recorder{TB: t}
+	Golden(r, path, "a\nb\n")
+	Golden(r, path, "a\nc\n")
+	Golden(r, path+".missing", "")
+
+	if len(r.errors) != 2 ||
+		!strings.Contains(r.errors[0], "line 2:\n\tgot:  c\n\twant: b") ||
+		!strings.Contains(r.errors[1], "run tests with -taggertest.update flag to create it") {
+		t.Errorf("unexpected errors:\n%s", strings.Join(r.errors, "\n"))
+	}
+}
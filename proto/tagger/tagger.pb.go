@@ -21,6 +21,241 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Tag is Go struct field tag, e.g. bson:"name,omitempty".
+type Tag struct {
+	// Tag key, e.g. bson.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Tag name, e.g. name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Tag options, e.g. omitempty.
+	Options              []string `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tag) Reset()         { *m = Tag{} }
+func (m *Tag) String() string { return proto.CompactTextString(m) }
+func (*Tag) ProtoMessage()    {}
+func (*Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_234f295180e939ff, []int{0}
+}
+
+func (m *Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tag.Unmarshal(m, b)
+}
+func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+}
+func (m *Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tag.Merge(m, src)
+}
+func (m *Tag) XXX_Size() int {
+	return xxx_messageInfo_Tag.Size(m)
+}
+func (m *Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tag proto.InternalMessageInfo
+
+func (m *Tag) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Tag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tag) GetOptions() []string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// Field contains structured tags of field or oneof.
+// Example:
+// (tagger.field) = { tag: { key: "bson" name: "x" options: ["omitempty"] } hide: ["json"] }
+type Field struct {
+	// Multiple Tags can be specified.
+	Tag []*Tag `protobuf:"bytes,1,rep,name=tag,proto3" json:"tag,omitempty"`
+	// Tag keys (e.g. json, bson) to hide field from.
+	// It sets '-' tag name for the provided keys.
+	Hide []string `protobuf:"bytes,2,rep,name=hide,proto3" json:"hide,omitempty"`
+	// Profiles (e.g. storage) the annotation is active for.
+	// Annotation without profiles is always active.
+	// Annotation with profiles is active if one of them is selected by 'profile' parameter.
+	Profile              []string `protobuf:"bytes,3,rep,name=profile,proto3" json:"profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Field) Reset()         { *m = Field{} }
+func (m *Field) String() string { return proto.CompactTextString(m) }
+func (*Field) ProtoMessage()    {}
+func (*Field) Descriptor() ([]byte, []int) {
+	return fileDescriptor_234f295180e939ff, []int{1}
+}
+
+func (m *Field) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Field.Unmarshal(m, b)
+}
+func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Field.Marshal(b, m, deterministic)
+}
+func (m *Field) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Field.Merge(m, src)
+}
+func (m *Field) XXX_Size() int {
+	return xxx_messageInfo_Field.Size(m)
+}
+func (m *Field) XXX_DiscardUnknown() {
+	xxx_messageInfo_Field.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Field proto.InternalMessageInfo
+
+func (m *Field) GetTag() []*Tag {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *Field) GetHide() []string {
+	if m != nil {
+		return m.Hide
+	}
+	return nil
+}
+
+func (m *Field) GetProfile() []string {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+// TagSet is named set of tags is defined once per file (see 'tag_set' file option)
+// and applied to fields by name (see 'use' field option).
+// Tag names and options may contain Go template variables of the field:
+// {{.Name}}, {{.JSONName}}, {{.Number}}, {{.GoName}}, {{.Message}}.
+// Example:
+// option (tagger.tag_set) = { name: "audit" tags: "bson:\",omitempty\" graphql:\"{{.Name}},optional\"" };
+type TagSet struct {
+	// Tag set name is referenced by 'use' options.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Multiple Tags can be specified in struct tag format.
+	Tags string `protobuf:"bytes,2,opt,name=tags,proto3" json:"tags,omitempty"`
+	// Multiple structured Tags can be specified.
+	Tag []*Tag `protobuf:"bytes,3,rep,name=tag,proto3" json:"tag,omitempty"`
+	// Tag keys (e.g. json, bson) to hide field from.
+	Hide []string `protobuf:"bytes,4,rep,name=hide,proto3" json:"hide,omitempty"`
+	// Profiles (e.g. storage) the tag set is active for.
+	// Tag set without profiles is always active.
+	// Tag set with profiles is active if one of them is selected by 'profile' parameter,
+	// otherwise it is ignored by fields are using it.
+	Profile              []string `protobuf:"bytes,5,rep,name=profile,proto3" json:"profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagSet) Reset()         { *m = TagSet{} }
+func (m *TagSet) String() string { return proto.CompactTextString(m) }
+func (*TagSet) ProtoMessage()    {}
+func (*TagSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_234f295180e939ff, []int{2}
+}
+
+func (m *TagSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagSet.Unmarshal(m, b)
+}
+func (m *TagSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagSet.Marshal(b, m, deterministic)
+}
+func (m *TagSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagSet.Merge(m, src)
+}
+func (m *TagSet) XXX_Size() int {
+	return xxx_messageInfo_TagSet.Size(m)
+}
+func (m *TagSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagSet proto.InternalMessageInfo
+
+func (m *TagSet) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TagSet) GetTags() string {
+	if m != nil {
+		return m.Tags
+	}
+	return ""
+}
+
+func (m *TagSet) GetTag() []*Tag {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+func (m *TagSet) GetHide() []string {
+	if m != nil {
+		return m.Hide
+	}
+	return nil
+}
+
+func (m *TagSet) GetProfile() []string {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+var E_TagSet = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FileOptions)(nil),
+	ExtensionType: ([]*TagSet)(nil),
+	Field:         847939,
+	Name:          "tagger.tag_set",
+	Tag:           "bytes,847939,rep,name=tag_set",
+	Filename:      "tagger.proto",
+}
+
+var E_MessageUse = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MessageOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         847939,
+	Name:          "tagger.message_use",
+	Tag:           "bytes,847939,opt,name=message_use",
+	Filename:      "tagger.proto",
+}
+
+var E_MessageSkipAuto = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MessageOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         847940,
+	Name:          "tagger.message_skip_auto",
+	Tag:           "varint,847940,opt,name=message_skip_auto",
+	Filename:      "tagger.proto",
+}
+
 var E_Tags = &proto.ExtensionDesc{
 	ExtendedType:  (*descriptor.FieldOptions)(nil),
 	ExtensionType: (*string)(nil),
@@ -30,6 +265,42 @@ var E_Tags = &proto.ExtensionDesc{
 	Filename:      "tagger.proto",
 }
 
+var E_Hide = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         847940,
+	Name:          "tagger.hide",
+	Tag:           "bytes,847940,opt,name=hide",
+	Filename:      "tagger.proto",
+}
+
+var E_Field = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: ([]*Field)(nil),
+	Field:         847941,
+	Name:          "tagger.field",
+	Tag:           "bytes,847941,rep,name=field",
+	Filename:      "tagger.proto",
+}
+
+var E_Use = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         847942,
+	Name:          "tagger.use",
+	Tag:           "bytes,847942,opt,name=use",
+	Filename:      "tagger.proto",
+}
+
+var E_SkipAuto = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         847943,
+	Name:          "tagger.skip_auto",
+	Tag:           "varint,847943,opt,name=skip_auto",
+	Filename:      "tagger.proto",
+}
+
 var E_OneofTags = &proto.ExtensionDesc{
 	ExtendedType:  (*descriptor.OneofOptions)(nil),
 	ExtensionType: (*string)(nil),
@@ -39,25 +310,95 @@ var E_OneofTags = &proto.ExtensionDesc{
 	Filename:      "tagger.proto",
 }
 
+var E_OneofHide = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.OneofOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         847940,
+	Name:          "tagger.oneof_hide",
+	Tag:           "bytes,847940,opt,name=oneof_hide",
+	Filename:      "tagger.proto",
+}
+
+var E_OneofField = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.OneofOptions)(nil),
+	ExtensionType: ([]*Field)(nil),
+	Field:         847941,
+	Name:          "tagger.oneof_field",
+	Tag:           "bytes,847941,rep,name=oneof_field",
+	Filename:      "tagger.proto",
+}
+
+var E_OneofUse = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.OneofOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         847942,
+	Name:          "tagger.oneof_use",
+	Tag:           "bytes,847942,opt,name=oneof_use",
+	Filename:      "tagger.proto",
+}
+
+var E_OneofSkipAuto = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.OneofOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         847943,
+	Name:          "tagger.oneof_skip_auto",
+	Tag:           "varint,847943,opt,name=oneof_skip_auto",
+	Filename:      "tagger.proto",
+}
+
 func init() {
+	proto.RegisterType((*Tag)(nil), "tagger.Tag")
+	proto.RegisterType((*Field)(nil), "tagger.Field")
+	proto.RegisterType((*TagSet)(nil), "tagger.TagSet")
+	proto.RegisterExtension(E_TagSet)
+	proto.RegisterExtension(E_MessageUse)
+	proto.RegisterExtension(E_MessageSkipAuto)
 	proto.RegisterExtension(E_Tags)
+	proto.RegisterExtension(E_Hide)
+	proto.RegisterExtension(E_Field)
+	proto.RegisterExtension(E_Use)
+	proto.RegisterExtension(E_SkipAuto)
 	proto.RegisterExtension(E_OneofTags)
+	proto.RegisterExtension(E_OneofHide)
+	proto.RegisterExtension(E_OneofField)
+	proto.RegisterExtension(E_OneofUse)
+	proto.RegisterExtension(E_OneofSkipAuto)
 }
 
 func init() { proto.RegisterFile("tagger.proto", fileDescriptor_234f295180e939ff) }
 
 var fileDescriptor_234f295180e939ff = []byte{
-	// 179 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x49, 0x4c, 0x4f,
-	0x4f, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0xa4, 0x14, 0xd2, 0xf3,
-	0xf3, 0xd3, 0x73, 0x52, 0xf5, 0xc1, 0xa2, 0x49, 0xa5, 0x69, 0xfa, 0x29, 0xa9, 0xc5, 0xc9, 0x45,
-	0x99, 0x05, 0x25, 0xf9, 0x50, 0x95, 0x56, 0xc6, 0x5c, 0x2c, 0x25, 0x89, 0xe9, 0xc5, 0x42, 0xb2,
-	0x7a, 0x10, 0xa5, 0x7a, 0x30, 0xa5, 0x7a, 0x6e, 0x99, 0xa9, 0x39, 0x29, 0xfe, 0x05, 0x25, 0x99,
-	0xf9, 0x79, 0xc5, 0x12, 0x87, 0x1f, 0x18, 0x2b, 0x30, 0x6a, 0x70, 0x06, 0x81, 0x15, 0x5b, 0xd9,
-	0x71, 0x71, 0xe5, 0xe7, 0xa5, 0xe6, 0xa7, 0xc5, 0xe3, 0xd0, 0xea, 0x0f, 0x92, 0x44, 0xd7, 0xca,
-	0x09, 0xd6, 0x12, 0x92, 0x98, 0x5e, 0xec, 0x64, 0x13, 0x65, 0x95, 0x9e, 0x59, 0x92, 0x51, 0x9a,
-	0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x98, 0x5b, 0x9c, 0x9f, 0x9d, 0x9f, 0x03, 0x71, 0x64, 0xb2,
-	0x6e, 0x7a, 0x6a, 0x9e, 0x6e, 0x7a, 0x3e, 0xc4, 0x03, 0x10, 0x31, 0x7d, 0x08, 0xc7, 0x1a, 0x42,
-	0x25, 0xb1, 0x81, 0x05, 0x8d, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd0, 0x97, 0x99, 0x2f, 0xf3,
-	0x00, 0x00, 0x00,
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xcf, 0x8e, 0x12, 0x41,
+	0x10, 0xc6, 0x83, 0x03, 0x2c, 0x14, 0xae, 0xab, 0x73, 0x22, 0x46, 0x23, 0xe1, 0xc4, 0x65, 0x87,
+	0x28, 0xb7, 0x71, 0x63, 0xe2, 0x46, 0x77, 0xbd, 0x6c, 0x48, 0x86, 0xf1, 0xe2, 0x85, 0x34, 0x50,
+	0xf4, 0x76, 0x18, 0xe8, 0x09, 0xdd, 0x73, 0xf0, 0xe0, 0xd3, 0x89, 0xfa, 0x1a, 0x3e, 0x8a, 0xa9,
+	0xfe, 0xb3, 0x32, 0xbb, 0xb0, 0x73, 0xa2, 0xaa, 0xba, 0xbe, 0xaf, 0xaa, 0x7f, 0x74, 0x06, 0x9e,
+	0x6a, 0xc6, 0x39, 0x6e, 0xa3, 0x7c, 0x2b, 0xb5, 0x0c, 0x9b, 0x36, 0x7b, 0xd9, 0xe3, 0x52, 0xf2,
+	0x0c, 0x87, 0xa6, 0x3a, 0x2b, 0x96, 0xc3, 0x05, 0xaa, 0xf9, 0x56, 0xe4, 0x5a, 0xba, 0xce, 0xfe,
+	0x67, 0x08, 0x52, 0xc6, 0xc3, 0xe7, 0x10, 0xac, 0xf0, 0x7b, 0xb7, 0xd6, 0xab, 0x0d, 0xda, 0x09,
+	0x85, 0x61, 0x08, 0xf5, 0x0d, 0x5b, 0x63, 0xf7, 0x89, 0x29, 0x99, 0x38, 0xec, 0xc2, 0x89, 0xcc,
+	0xb5, 0x90, 0x1b, 0xd5, 0x0d, 0x7a, 0xc1, 0xa0, 0x9d, 0xf8, 0xb4, 0x9f, 0x42, 0xe3, 0x4a, 0x60,
+	0xb6, 0x08, 0x5f, 0x43, 0xa0, 0x19, 0xef, 0xd6, 0x7a, 0xc1, 0xa0, 0xf3, 0xae, 0x13, 0xb9, 0xad,
+	0x52, 0xc6, 0x13, 0xaa, 0x93, 0xeb, 0xad, 0x58, 0x90, 0x2b, 0xc9, 0x4d, 0x4c, 0xae, 0xf9, 0x56,
+	0x2e, 0x45, 0x86, 0xde, 0xd5, 0xa5, 0xfd, 0x1f, 0xd0, 0x4c, 0x19, 0x9f, 0xa0, 0xbe, 0xdb, 0xa6,
+	0xb6, 0xb7, 0x4d, 0x08, 0x75, 0xcd, 0xb8, 0xf2, 0x1b, 0x52, 0xec, 0xc7, 0x07, 0x15, 0xe3, 0xeb,
+	0x87, 0xc7, 0x37, 0x4a, 0xe3, 0xe3, 0x6b, 0x38, 0xd1, 0x8c, 0x4f, 0x15, 0xea, 0xf0, 0x55, 0x64,
+	0x49, 0x46, 0x9e, 0x64, 0x74, 0x25, 0x32, 0x1c, 0x3b, 0x18, 0x3f, 0xff, 0x8e, 0xcc, 0xc0, 0x67,
+	0x7b, 0x03, 0x27, 0xa8, 0x13, 0xfa, 0x1b, 0x26, 0xa8, 0xe3, 0x4b, 0xe8, 0xac, 0x51, 0x29, 0xc6,
+	0x71, 0x5a, 0x28, 0x0c, 0xdf, 0x3c, 0x30, 0xbb, 0xb1, 0xa7, 0xfb, 0x7e, 0x74, 0x27, 0x70, 0xaa,
+	0xaf, 0x0a, 0xe3, 0x1b, 0x78, 0xe1, 0x3d, 0xd4, 0x4a, 0xe4, 0x53, 0x56, 0x68, 0x59, 0xed, 0xb4,
+	0x33, 0x4e, 0xad, 0xe4, 0xcc, 0x69, 0x27, 0x2b, 0x91, 0x7f, 0x2c, 0xb4, 0x8c, 0x47, 0xe0, 0x80,
+	0x1d, 0xb8, 0x18, 0x66, 0x8b, 0xfb, 0x9b, 0x98, 0x66, 0x12, 0x19, 0x64, 0x15, 0xa2, 0x9d, 0x17,
+	0x51, 0x73, 0xfc, 0x09, 0x1a, 0x4b, 0xfb, 0x34, 0x1e, 0x57, 0xfd, 0x72, 0x10, 0x4f, 0x3d, 0x44,
+	0x73, 0x9a, 0x58, 0x71, 0xfc, 0x16, 0x02, 0x42, 0x57, 0xe1, 0xf1, 0xdb, 0x4d, 0xa6, 0xde, 0xf8,
+	0x02, 0xda, 0xff, 0x49, 0x55, 0x08, 0xff, 0x38, 0x4e, 0x2d, 0xe5, 0x01, 0x7d, 0x00, 0x90, 0x1b,
+	0x94, 0xcb, 0xe9, 0x11, 0x4c, 0x63, 0x3a, 0xbc, 0x8f, 0xa9, 0x6d, 0x24, 0x29, 0xb1, 0xba, 0xd3,
+	0x1f, 0x21, 0x56, 0xd2, 0xef, 0x4a, 0xfa, 0x2f, 0x84, 0x6d, 0x0c, 0x1d, 0xab, 0x3f, 0x06, 0xaf,
+	0x64, 0x70, 0x04, 0x9e, 0x5d, 0xc1, 0xc4, 0x84, 0xc3, 0x1a, 0x1e, 0xe6, 0x58, 0xb2, 0xf3, 0x1c,
+	0x5b, 0x46, 0x41, 0xcf, 0xef, 0x1a, 0xce, 0xac, 0xfa, 0x31, 0xa4, 0x25, 0x0f, 0x8f, 0xf4, 0xd4,
+	0xe8, 0xfc, 0xc3, 0xbb, 0xbc, 0xf8, 0x16, 0x73, 0xa1, 0x6f, 0x8b, 0x59, 0x34, 0x97, 0xeb, 0x21,
+	0x5b, 0x2b, 0xb9, 0x92, 0x99, 0xfd, 0x40, 0xcd, 0xcf, 0x39, 0x6e, 0xce, 0xb9, 0xb4, 0x57, 0xb0,
+	0xb5, 0xa1, 0x4d, 0xde, 0xdb, 0x9f, 0x59, 0xd3, 0x14, 0x47, 0xff, 0x06, 0x00, 0x1d, 0x95, 0x61,
+	0x5a, 0xef, 0x04, 0x00, 0x00,
 }
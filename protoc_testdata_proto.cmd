@@ -0,0 +1,10 @@
+@protoc --proto_path=./pkg/tagger/testdata --go_out=./pkg/tagger/testdata protojson.proto
+@protoc --proto_path=./pkg/tagger/testdata --include_imports --include_source_info --descriptor_set_out=./pkg/tagger/testdata/protojson.protoset protojson.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./test --go_out=./pkg/tagger/testdata data.proto names.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./test --include_imports --include_source_info --descriptor_set_out=./pkg/tagger/testdata/test.protoset data.proto names.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/extract --go_out=./pkg/tagger/testdata/extract extract.proto noimport.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/extract --include_imports --include_source_info --descriptor_set_out=./pkg/tagger/testdata/extract/extract.protoset extract.proto noimport.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/gogo --gogo_out=./pkg/tagger/testdata/gogo gogodata.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/gogo --include_imports --include_source_info --descriptor_set_out=./pkg/tagger/testdata/gogo/gogodata.protoset gogodata.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/rules --go_out=./pkg/tagger/testdata/rules conflict.proto sets.proto importer.proto unknown_set.proto template_error.proto skip.proto legacy.proto
+@protoc --proto_path=./third_party --proto_path=./proto --proto_path=./pkg/tagger/testdata/rules --include_imports --include_source_info --descriptor_set_out=./pkg/tagger/testdata/rules/rules.protoset conflict.proto sets.proto importer.proto unknown_set.proto template_error.proto skip.proto legacy.proto legacy_dep.proto
@@ -21,12 +21,22 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Data is test message.
 type Data struct {
-	ValVvall string `protobuf:"bytes,1,opt,name=val_vvall,json=valVvall,proto3" json:"val_vvall,omitempty" graphql:"name11,optional" bson:"name12,omitempty"`
+	// val_vvall is "string" value
+	// with `multiline` comment.
+	ValVvall string "protobuf:\"bytes,1,opt,name=val_vvall,json=valVvall,proto3\" json:\"val_vvall,omitempty\" graphql:\"name11,optional\" bson:\"name12,omitempty\" doc:\"val_vvall is \\\"string\\\" value with `multiline` comment.\""
+	// one_of is oneof value.
+	//
 	// Types that are valid to be assigned to OneOf:
 	//	*Data_A
 	//	*Data_BJk
-	OneOf                isData_OneOf `protobuf_oneof:"one_of" graphql:"withNewTags,optional"`
+	OneOf                isData_OneOf `protobuf_oneof:"one_of" graphql:"withNewTags,optional" bson:"one_of" doc:"one_of is oneof value."`
+	NestedValue          *DataNested  `protobuf:"bytes,7,opt,name=nested_value,json=nestedValue,proto3" json:"nested_value,omitempty" bson:"nested_value" graphql:"nested_value"`
+	Int64Value           int64        `protobuf:"varint,8,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty" bson:"int64_value,omitempty" graphql:"int64_value,optional" validate:"required" msgpack:"8"`
+	Uint64Values         []uint64     `protobuf:"varint,9,rep,packed,name=uint64_values,json=uint64Values,proto3" json:"uint64_values,omitempty" bson:"uint64_values" graphql:"uint64_values"`
+	Password             string       `protobuf:"bytes,10,opt,name=password,proto3" json:"-" bson:"-" graphql:"password"`
+	Structured           string       `protobuf:"bytes,11,opt,name=structured,proto3" json:"-" graphql:"structured,optional" bson:"x,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-" bson:"-"`
 	XXX_unrecognized     []byte       `json:"-" bson:"-"`
 	XXX_sizecache        int32        `json:"-" bson:"-"`
@@ -69,7 +79,7 @@ type isData_OneOf interface {
 }
 
 type Data_A struct {
-	A string `protobuf:"bytes,5,opt,name=a,proto3,oneof" bson:"A"`
+	A string `protobuf:"bytes,5,opt,name=a,proto3,oneof" bson:"A" graphql:"a" doc:"a is string oneof value."`
 }
 
 type Data_BJk struct {
@@ -101,6 +111,41 @@ func (m *Data) GetBJk() int32 {
 	return 0
 }
 
+func (m *Data) GetNestedValue() *DataNested {
+	if m != nil {
+		return m.NestedValue
+	}
+	return nil
+}
+
+func (m *Data) GetInt64Value() int64 {
+	if m != nil {
+		return m.Int64Value
+	}
+	return 0
+}
+
+func (m *Data) GetUint64Values() []uint64 {
+	if m != nil {
+		return m.Uint64Values
+	}
+	return nil
+}
+
+func (m *Data) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *Data) GetStructured() string {
+	if m != nil {
+		return m.Structured
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Data) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -149,7 +194,8 @@ func (m *DataNested) GetXVal2Value() string {
 }
 
 type DataNested_OneMore_Nested struct {
-	Val3                 string   `protobuf:"bytes,1,opt,name=val3,proto3" json:"val3,omitempty"`
+	// val3 is nested value.
+	Val3                 string   `protobuf:"bytes,1,opt,name=val3,proto3" json:"val3,omitempty" bson:"val3,omitempty" graphql:"val3,optional" validate:"required" doc:"val3 is nested value."`
 	XXX_NoUnkeyedLiteral struct{} `json:"-" bson:"-"`
 	XXX_unrecognized     []byte   `json:"-" bson:"-"`
 	XXX_sizecache        int32    `json:"-" bson:"-"`
@@ -196,24 +242,41 @@ func init() {
 func init() { proto.RegisterFile("data.proto", fileDescriptor_871986018790d2fd) }
 
 var fileDescriptor_871986018790d2fd = []byte{
-	// 297 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x4f, 0x4b, 0xf3, 0x30,
-	0x1c, 0xc7, 0x9f, 0x3c, 0xeb, 0xca, 0x16, 0xff, 0x20, 0xf1, 0x32, 0x0a, 0x4a, 0xa9, 0x0a, 0x3b,
-	0x4c, 0xa5, 0x1d, 0x78, 0xf0, 0x66, 0xf1, 0x20, 0x03, 0x27, 0x54, 0xa9, 0xc7, 0xf0, 0x2b, 0x8b,
-	0x5d, 0x6d, 0x9a, 0xd4, 0x36, 0x66, 0x78, 0x17, 0x7c, 0x0f, 0x1e, 0x7c, 0x61, 0xbe, 0x1a, 0x49,
-	0x23, 0x8a, 0xa0, 0x97, 0x24, 0x24, 0xdf, 0x7c, 0x3e, 0x5f, 0x7e, 0x18, 0x2f, 0x40, 0xc1, 0x51,
-	0xdd, 0x48, 0x25, 0x89, 0xa3, 0x58, 0xab, 0xbc, 0x6d, 0x05, 0x79, 0xce, 0x9a, 0x63, 0xbb, 0xd9,
-	0xa7, 0xe0, 0xfd, 0x3f, 0x76, 0xce, 0x41, 0x01, 0xb9, 0xc6, 0x43, 0x0d, 0x9c, 0x6a, 0x0d, 0x9c,
-	0x8f, 0x90, 0x8f, 0xc6, 0xc3, 0xf8, 0xe4, 0xf5, 0xf9, 0xad, 0x17, 0xe6, 0x0d, 0xd4, 0xcb, 0x07,
-	0x7e, 0x1a, 0x08, 0xa8, 0x58, 0x18, 0x4e, 0x64, 0xad, 0x0a, 0x29, 0x80, 0x07, 0x7e, 0xd6, 0x4a,
-	0xf1, 0x79, 0x1d, 0x4d, 0x64, 0x55, 0x28, 0x56, 0xd5, 0xea, 0x29, 0x48, 0x06, 0x1a, 0x78, 0x6a,
-	0x38, 0x64, 0x07, 0x23, 0x18, 0xf5, 0x3b, 0xd8, 0x86, 0x81, 0x0d, 0xec, 0x97, 0xb3, 0xe0, 0xe2,
-	0x5f, 0x82, 0x80, 0x1c, 0x60, 0x27, 0xa3, 0xf7, 0xe5, 0xc8, 0xf5, 0xd1, 0xb8, 0x1f, 0x6f, 0x99,
-	0xc4, 0x9a, 0x4d, 0x64, 0x74, 0x56, 0x9a, 0x50, 0x2f, 0x9b, 0x95, 0xde, 0x0b, 0xc2, 0xae, 0x60,
-	0xad, 0x62, 0x0b, 0x72, 0x8b, 0xd7, 0x29, 0xd5, 0xc0, 0x23, 0xb3, 0x3c, 0xb2, 0xbf, 0x8b, 0x46,
-	0xbf, 0x17, 0x8d, 0x7e, 0x14, 0x1d, 0xa6, 0xc0, 0xa3, 0xd4, 0x80, 0xbc, 0x7d, 0xbc, 0x79, 0x25,
-	0xd8, 0xa5, 0x6c, 0x18, 0x9d, 0x5b, 0x15, 0xc1, 0x8e, 0x06, 0x3e, 0xb5, 0x8a, 0xa4, 0x3b, 0xc7,
-	0x87, 0xd8, 0x95, 0x82, 0x51, 0x79, 0x47, 0xf6, 0x8c, 0x72, 0xf7, 0x4b, 0xb9, 0x2a, 0xd4, 0x72,
-	0xce, 0x56, 0x37, 0x90, 0xb7, 0xdf, 0xde, 0xcc, 0xed, 0x66, 0x3c, 0xfd, 0x08, 0x00, 0x00, 0xff,
-	0xff, 0xbe, 0xa6, 0xa0, 0x12, 0x8c, 0x01, 0x00, 0x00,
+	// 561 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x3f, 0x6f, 0xd3, 0x40,
+	0x14, 0xaf, 0xb1, 0xf3, 0xef, 0x25, 0x45, 0xea, 0x15, 0x90, 0x15, 0xa9, 0xc5, 0x72, 0x97, 0x08,
+	0x85, 0x40, 0xdc, 0xaa, 0x43, 0x25, 0x06, 0x22, 0x86, 0xaa, 0xa8, 0x45, 0x32, 0xa8, 0x8c, 0xd6,
+	0x73, 0x7d, 0x38, 0x6e, 0x6c, 0x9f, 0xeb, 0x3b, 0xbb, 0xa0, 0x2a, 0x9b, 0xbf, 0x01, 0x1b, 0x43,
+	0x06, 0x46, 0x46, 0x26, 0x3e, 0x06, 0x1f, 0x09, 0x9d, 0x2f, 0x4d, 0x82, 0x04, 0xd3, 0x59, 0x77,
+	0xbf, 0x3f, 0xef, 0xfd, 0xde, 0x33, 0x40, 0x80, 0x02, 0x47, 0x59, 0xce, 0x04, 0x23, 0x86, 0xa0,
+	0x5c, 0xf4, 0x77, 0x05, 0x86, 0x21, 0xcd, 0x5f, 0xa8, 0x43, 0x3d, 0xd9, 0xbf, 0x1b, 0x60, 0xbc,
+	0x41, 0x81, 0xe4, 0x3d, 0x74, 0x4a, 0x8c, 0xbd, 0xb2, 0xc4, 0x38, 0x36, 0x35, 0x4b, 0x1b, 0x74,
+	0x26, 0xc7, 0xdf, 0xaa, 0x85, 0x3e, 0x0e, 0x73, 0xcc, 0xa6, 0x37, 0xf1, 0x89, 0x9d, 0x62, 0x42,
+	0xc7, 0xe3, 0x21, 0xcb, 0x44, 0xc4, 0x52, 0x8c, 0x6d, 0xcb, 0xe7, 0x2c, 0x5d, 0x5e, 0x3b, 0x43,
+	0x96, 0x44, 0x82, 0x26, 0x99, 0xf8, 0x62, 0xbb, 0xed, 0x12, 0xe3, 0x4b, 0xa9, 0x43, 0xf6, 0x40,
+	0x43, 0xb3, 0x51, 0x8b, 0x6d, 0x4b, 0xb1, 0xb6, 0xa2, 0xbc, 0xb6, 0x4f, 0xb7, 0x5c, 0x0d, 0xc9,
+	0x33, 0x30, 0x7c, 0xef, 0x7a, 0x66, 0x36, 0x2d, 0x6d, 0xd0, 0x98, 0x3c, 0x96, 0x88, 0xae, 0x42,
+	0xf8, 0xde, 0xd9, 0xcc, 0xfe, 0x55, 0x2d, 0x74, 0xed, 0x74, 0xcb, 0xd5, 0xfd, 0xb3, 0x19, 0x39,
+	0x82, 0x5e, 0x4a, 0xb9, 0xa0, 0x81, 0x57, 0x62, 0x5c, 0x50, 0xb3, 0x65, 0x69, 0x83, 0xae, 0xb3,
+	0x33, 0x92, 0xad, 0x8d, 0x64, 0x07, 0x23, 0xf5, 0xec, 0x76, 0xd5, 0x79, 0x29, 0x51, 0xc4, 0x81,
+	0x6e, 0x94, 0x8a, 0xe3, 0xa3, 0x25, 0xa9, 0x6d, 0x69, 0x03, 0x7d, 0xb2, 0xf3, 0xb3, 0x5a, 0xe8,
+	0x3d, 0x2c, 0x82, 0x48, 0x0c, 0xd3, 0x22, 0xf1, 0x69, 0xee, 0x42, 0x8d, 0x52, 0x9c, 0x03, 0xd8,
+	0x2e, 0x36, 0x48, 0xdc, 0xec, 0x58, 0xfa, 0xc0, 0x70, 0x7b, 0xc5, 0x1a, 0xc3, 0xc9, 0x39, 0xb4,
+	0x33, 0xe4, 0xfc, 0x96, 0xe5, 0x81, 0x09, 0x75, 0x83, 0xe3, 0xef, 0xd5, 0x42, 0xef, 0x5c, 0x73,
+	0x96, 0x0e, 0x65, 0x0f, 0x3f, 0xaa, 0x85, 0xbe, 0xdf, 0x6f, 0x71, 0xc1, 0x72, 0x0c, 0x29, 0xec,
+	0xc2, 0x83, 0xc0, 0x27, 0xdb, 0xf7, 0x1c, 0x6f, 0x8a, 0x7c, 0xea, 0xae, 0x24, 0x48, 0xa5, 0x01,
+	0x70, 0x91, 0x17, 0x57, 0xa2, 0xc8, 0x69, 0x60, 0x76, 0x6b, 0xc5, 0x40, 0x06, 0xb2, 0xb7, 0xca,
+	0x7f, 0xfd, 0xbe, 0x9e, 0x81, 0x74, 0x79, 0x05, 0x8f, 0xc0, 0x90, 0x96, 0x44, 0xfb, 0xdc, 0xef,
+	0xac, 0xe6, 0x00, 0x4f, 0xc9, 0x86, 0x64, 0xbf, 0x7d, 0xcf, 0x81, 0xd6, 0x52, 0x91, 0x18, 0xb2,
+	0x58, 0x77, 0x13, 0xf4, 0x55, 0x83, 0xa6, 0x8a, 0x8f, 0x7c, 0x84, 0x9e, 0x27, 0x03, 0x70, 0x96,
+	0xd1, 0xfd, 0x77, 0x25, 0x9c, 0x7f, 0xaf, 0x84, 0xf3, 0xd7, 0x4a, 0x74, 0x2e, 0x31, 0x76, 0xea,
+	0xe8, 0xfa, 0x2f, 0xe1, 0xe1, 0xbb, 0x94, 0x9e, 0xb3, 0x9c, 0x7a, 0x17, 0xca, 0x8a, 0x80, 0x51,
+	0x62, 0x7c, 0xa8, 0x2c, 0xdc, 0xfa, 0xfb, 0x04, 0xa4, 0x51, 0xa3, 0x9e, 0xd1, 0xe4, 0x39, 0x34,
+	0x59, 0x4a, 0x3d, 0xf6, 0x89, 0x1c, 0xc8, 0xdb, 0xfd, 0x95, 0xfd, 0x6d, 0x24, 0xa6, 0x17, 0xf4,
+	0xf6, 0x03, 0x86, 0x7c, 0x5d, 0xc3, 0xa4, 0x90, 0xa0, 0xb7, 0xa0, 0xc8, 0x64, 0xa2, 0x4a, 0xda,
+	0x28, 0xc6, 0x5a, 0xf1, 0xef, 0xee, 0x46, 0x17, 0x98, 0xd0, 0xf9, 0x7c, 0xa3, 0x83, 0x12, 0xe3,
+	0x28, 0x40, 0x41, 0x4f, 0xec, 0x9c, 0xde, 0x14, 0x51, 0x4e, 0x03, 0x5b, 0xea, 0x59, 0xd0, 0x54,
+	0xab, 0xd2, 0x7f, 0x02, 0xad, 0x84, 0x87, 0x19, 0x5e, 0xcd, 0x48, 0x57, 0x0a, 0xd4, 0xb7, 0xf3,
+	0xb9, 0xdf, 0xac, 0x7f, 0xa8, 0xc3, 0x3f, 0x03, 0x00, 0xdc, 0xfc, 0x68, 0x00, 0x79, 0x03, 0x00,
+	0x00,
 }
@@ -0,0 +1,401 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: names.proto
+
+package test
+
+import (
+	fmt "fmt"
+	_ "github.com/amsokol/protoc-gen-gotagger/proto/tagger"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Names contains fields with names are tricky to convert to Go names.
+// Every field is annotated to make sure its Go field is found.
+type Names struct {
+	// reset conflicts with generated Reset method
+	Reset_      string `protobuf:"bytes,1,opt,name=reset,proto3" json:"reset,omitempty" db:"reset" bson:"reset" graphql:"reset" doc:"reset conflicts with generated Reset method"`
+	Descriptor_ string `protobuf:"bytes,2,opt,name=descriptor,proto3" json:"descriptor,omitempty" db:"descriptor" bson:"descriptor" graphql:"descriptor"`
+	// name and get_name conflict with getter of each other
+	Name     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty" db:"name" bson:"name" graphql:"name" doc:"name and get_name conflict with getter of each other"`
+	GetName_ string `protobuf:"bytes,4,opt,name=get_name,json=getName,proto3" json:"get_name,omitempty" db:"get_name" bson:"get_name" graphql:"get_name"`
+	// digits after underscores
+	Field_1Value      string `protobuf:"bytes,5,opt,name=field_1_value,json=field1Value,proto3" json:"field_1_value,omitempty" db:"field_1_value" bson:"field_1_value" graphql:"field_1_value" doc:"digits after underscores"`
+	X_2Y              string `protobuf:"bytes,6,opt,name=x_2y,json=x2y,proto3" json:"x_2y,omitempty" db:"x_2y" bson:"x_2y" graphql:"x_2y"`
+	XLeading          string `protobuf:"bytes,7,opt,name=_leading,json=Leading,proto3" json:"_leading,omitempty" db:"_leading" bson:"_leading" graphql:"_leading"`
+	Double_Underscore string `protobuf:"bytes,8,opt,name=double__underscore,json=doubleUnderscore,proto3" json:"double__underscore,omitempty" db:"double__underscore" bson:"double__underscore" graphql:"double__underscore"`
+	CamelCase         string `protobuf:"bytes,9,opt,name=camelCase,proto3" json:"camelCase,omitempty" db:"camelCase" bson:"camelCase" graphql:"camelCase"`
+	HTTPServer        string `protobuf:"bytes,10,opt,name=HTTPServer,proto3" json:"HTTPServer,omitempty" db:"HTTPServer" bson:"HTTPServer" graphql:"HTTPServer"`
+	// Types that are valid to be assigned to String_:
+	//	*Names_Choice_
+	//	*Names_Reset_2
+	String_              isNames_String_      `protobuf_oneof:"string" db:"string" bson:"string" graphql:"string"`
+	Deeper               *NamesInnerDeeperOne `protobuf:"bytes,13,opt,name=deeper,proto3" json:"deeper,omitempty" db:"deeper" bson:"deeper" graphql:"deeper"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-" bson:"-"`
+	XXX_unrecognized     []byte               `json:"-" bson:"-"`
+	XXX_sizecache        int32                `json:"-" bson:"-"`
+}
+
+func (m *Names) Reset()         { *m = Names{} }
+func (m *Names) String() string { return proto.CompactTextString(m) }
+func (*Names) ProtoMessage()    {}
+func (*Names) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f4268625867c617c, []int{0}
+}
+
+func (m *Names) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Names.Unmarshal(m, b)
+}
+func (m *Names) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Names.Marshal(b, m, deterministic)
+}
+func (m *Names) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Names.Merge(m, src)
+}
+func (m *Names) XXX_Size() int {
+	return xxx_messageInfo_Names.Size(m)
+}
+func (m *Names) XXX_DiscardUnknown() {
+	xxx_messageInfo_Names.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Names proto.InternalMessageInfo
+
+func (m *Names) GetReset_() string {
+	if m != nil {
+		return m.Reset_
+	}
+	return ""
+}
+
+func (m *Names) GetDescriptor_() string {
+	if m != nil {
+		return m.Descriptor_
+	}
+	return ""
+}
+
+func (m *Names) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Names) GetGetName_() string {
+	if m != nil {
+		return m.GetName_
+	}
+	return ""
+}
+
+func (m *Names) GetField_1Value() string {
+	if m != nil {
+		return m.Field_1Value
+	}
+	return ""
+}
+
+func (m *Names) GetX_2Y() string {
+	if m != nil {
+		return m.X_2Y
+	}
+	return ""
+}
+
+func (m *Names) GetXLeading() string {
+	if m != nil {
+		return m.XLeading
+	}
+	return ""
+}
+
+func (m *Names) GetDouble_Underscore() string {
+	if m != nil {
+		return m.Double_Underscore
+	}
+	return ""
+}
+
+func (m *Names) GetCamelCase() string {
+	if m != nil {
+		return m.CamelCase
+	}
+	return ""
+}
+
+func (m *Names) GetHTTPServer() string {
+	if m != nil {
+		return m.HTTPServer
+	}
+	return ""
+}
+
+type isNames_String_ interface {
+	isNames_String_()
+}
+
+type Names_Choice_ struct {
+	Choice string `protobuf:"bytes,11,opt,name=choice,proto3,oneof" db:"choice" bson:"choice" graphql:"choice"`
+}
+
+type Names_Reset_2 struct {
+	Reset_2 int32 `protobuf:"varint,12,opt,name=reset_2,json=reset2,proto3,oneof" db:"reset_2" bson:"reset_2" graphql:"reset_2"`
+}
+
+func (*Names_Choice_) isNames_String_() {}
+
+func (*Names_Reset_2) isNames_String_() {}
+
+func (m *Names) GetString_() isNames_String_ {
+	if m != nil {
+		return m.String_
+	}
+	return nil
+}
+
+func (m *Names) GetChoice() string {
+	if x, ok := m.GetString_().(*Names_Choice_); ok {
+		return x.Choice
+	}
+	return ""
+}
+
+func (m *Names) GetReset_2() int32 {
+	if x, ok := m.GetString_().(*Names_Reset_2); ok {
+		return x.Reset_2
+	}
+	return 0
+}
+
+func (m *Names) GetDeeper() *NamesInnerDeeperOne {
+	if m != nil {
+		return m.Deeper
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Names) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Names_Choice_)(nil),
+		(*Names_Reset_2)(nil),
+	}
+}
+
+// inner is nested message with leading lowercase name
+type NamesInner struct {
+	Value_2              string   `protobuf:"bytes,1,opt,name=value_2,json=value2,proto3" json:"value_2,omitempty" db:"value_2" bson:"value_2" graphql:"value_2"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" bson:"-"`
+	XXX_unrecognized     []byte   `json:"-" bson:"-"`
+	XXX_sizecache        int32    `json:"-" bson:"-"`
+}
+
+func (m *NamesInner) Reset()         { *m = NamesInner{} }
+func (m *NamesInner) String() string { return proto.CompactTextString(m) }
+func (*NamesInner) ProtoMessage()    {}
+func (*NamesInner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f4268625867c617c, []int{0, 0}
+}
+
+func (m *NamesInner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamesInner.Unmarshal(m, b)
+}
+func (m *NamesInner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamesInner.Marshal(b, m, deterministic)
+}
+func (m *NamesInner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamesInner.Merge(m, src)
+}
+func (m *NamesInner) XXX_Size() int {
+	return xxx_messageInfo_NamesInner.Size(m)
+}
+func (m *NamesInner) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamesInner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamesInner proto.InternalMessageInfo
+
+func (m *NamesInner) GetValue_2() string {
+	if m != nil {
+		return m.Value_2
+	}
+	return ""
+}
+
+type NamesInnerDeeperOne struct {
+	V                    string   `protobuf:"bytes,1,opt,name=v,proto3" json:"v,omitempty" db:"v" bson:"v" graphql:"v"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" bson:"-"`
+	XXX_unrecognized     []byte   `json:"-" bson:"-"`
+	XXX_sizecache        int32    `json:"-" bson:"-"`
+}
+
+func (m *NamesInnerDeeperOne) Reset()         { *m = NamesInnerDeeperOne{} }
+func (m *NamesInnerDeeperOne) String() string { return proto.CompactTextString(m) }
+func (*NamesInnerDeeperOne) ProtoMessage()    {}
+func (*NamesInnerDeeperOne) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f4268625867c617c, []int{0, 0, 0}
+}
+
+func (m *NamesInnerDeeperOne) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamesInnerDeeperOne.Unmarshal(m, b)
+}
+func (m *NamesInnerDeeperOne) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamesInnerDeeperOne.Marshal(b, m, deterministic)
+}
+func (m *NamesInnerDeeperOne) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamesInnerDeeperOne.Merge(m, src)
+}
+func (m *NamesInnerDeeperOne) XXX_Size() int {
+	return xxx_messageInfo_NamesInnerDeeperOne.Size(m)
+}
+func (m *NamesInnerDeeperOne) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamesInnerDeeperOne.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamesInnerDeeperOne proto.InternalMessageInfo
+
+func (m *NamesInnerDeeperOne) GetV() string {
+	if m != nil {
+		return m.V
+	}
+	return ""
+}
+
+// Choice conflicts with oneof wrapper of 'choice' field
+type Names_Choice struct {
+	C                    string   `protobuf:"bytes,1,opt,name=c,proto3" json:"c,omitempty" db:"c" bson:"c" graphql:"c"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" bson:"-"`
+	XXX_unrecognized     []byte   `json:"-" bson:"-"`
+	XXX_sizecache        int32    `json:"-" bson:"-"`
+}
+
+func (m *Names_Choice) Reset()         { *m = Names_Choice{} }
+func (m *Names_Choice) String() string { return proto.CompactTextString(m) }
+func (*Names_Choice) ProtoMessage()    {}
+func (*Names_Choice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f4268625867c617c, []int{0, 1}
+}
+
+func (m *Names_Choice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Names_Choice.Unmarshal(m, b)
+}
+func (m *Names_Choice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Names_Choice.Marshal(b, m, deterministic)
+}
+func (m *Names_Choice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Names_Choice.Merge(m, src)
+}
+func (m *Names_Choice) XXX_Size() int {
+	return xxx_messageInfo_Names_Choice.Size(m)
+}
+func (m *Names_Choice) XXX_DiscardUnknown() {
+	xxx_messageInfo_Names_Choice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Names_Choice proto.InternalMessageInfo
+
+func (m *Names_Choice) GetC() string {
+	if m != nil {
+		return m.C
+	}
+	return ""
+}
+
+// lower_case is top level message with leading lowercase name
+type LowerCase struct {
+	Some_3DValue         string   `protobuf:"bytes,1,opt,name=some_3d_value,json=some3dValue,proto3" json:"some_3d_value,omitempty" db:"some_3d_value" bson:"some_3d_value" graphql:"some_3d_value"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" bson:"-"`
+	XXX_unrecognized     []byte   `json:"-" bson:"-"`
+	XXX_sizecache        int32    `json:"-" bson:"-"`
+}
+
+func (m *LowerCase) Reset()         { *m = LowerCase{} }
+func (m *LowerCase) String() string { return proto.CompactTextString(m) }
+func (*LowerCase) ProtoMessage()    {}
+func (*LowerCase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f4268625867c617c, []int{1}
+}
+
+func (m *LowerCase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LowerCase.Unmarshal(m, b)
+}
+func (m *LowerCase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LowerCase.Marshal(b, m, deterministic)
+}
+func (m *LowerCase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LowerCase.Merge(m, src)
+}
+func (m *LowerCase) XXX_Size() int {
+	return xxx_messageInfo_LowerCase.Size(m)
+}
+func (m *LowerCase) XXX_DiscardUnknown() {
+	xxx_messageInfo_LowerCase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LowerCase proto.InternalMessageInfo
+
+func (m *LowerCase) GetSome_3DValue() string {
+	if m != nil {
+		return m.Some_3DValue
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Names)(nil), "test.Names")
+	proto.RegisterType((*NamesInner)(nil), "test.Names.inner")
+	proto.RegisterType((*NamesInnerDeeperOne)(nil), "test.Names.inner.deeper_one")
+	proto.RegisterType((*Names_Choice)(nil), "test.Names.Choice")
+	proto.RegisterType((*LowerCase)(nil), "test.lower_case")
+}
+
+func init() { proto.RegisterFile("names.proto", fileDescriptor_f4268625867c617c) }
+
+var fileDescriptor_f4268625867c617c = []byte{
+	// 522 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x93, 0xc1, 0x6e, 0xd3, 0x4e,
+	0x10, 0xc6, 0xff, 0xfe, 0x37, 0x71, 0x9a, 0x71, 0xd3, 0x96, 0x2d, 0x52, 0xb7, 0x51, 0x0f, 0x65,
+	0x10, 0x22, 0xaa, 0x20, 0x28, 0x0e, 0x27, 0x7a, 0x4b, 0x25, 0x54, 0x10, 0x42, 0x68, 0x29, 0x9c,
+	0x90, 0x56, 0x8e, 0x3d, 0x84, 0x48, 0x8e, 0x1d, 0xad, 0x9d, 0xd0, 0xde, 0x79, 0x0a, 0x0e, 0x3c,
+	0x09, 0x0f, 0x87, 0x66, 0xec, 0x26, 0x69, 0x7a, 0xca, 0xee, 0xf7, 0xfd, 0xbe, 0xd9, 0x49, 0x66,
+	0x02, 0x41, 0x16, 0xcd, 0xa8, 0xe8, 0xcf, 0x5d, 0x5e, 0xe6, 0xaa, 0x51, 0x52, 0x51, 0x76, 0x8f,
+	0xca, 0x68, 0x32, 0x21, 0xf7, 0xaa, 0xfa, 0xa8, 0x2c, 0xfc, 0xeb, 0x43, 0xf3, 0x23, 0xa3, 0xea,
+	0x19, 0x34, 0x1d, 0x15, 0x54, 0x6a, 0xef, 0xcc, 0xeb, 0xb5, 0x47, 0x07, 0xbf, 0x7f, 0xfd, 0xd9,
+	0x81, 0x64, 0xfc, 0x06, 0x45, 0x45, 0x53, 0xb9, 0xea, 0x35, 0x40, 0x42, 0x45, 0xec, 0xa6, 0xf3,
+	0x32, 0x77, 0xfa, 0x7f, 0x61, 0x1f, 0x33, 0x7b, 0xc0, 0xec, 0xda, 0x42, 0xb3, 0xc1, 0x29, 0x84,
+	0x06, 0x37, 0xa4, 0x77, 0x84, 0xdf, 0x67, 0xbe, 0xcd, 0x3c, 0x8b, 0x68, 0xc4, 0x53, 0x2f, 0x61,
+	0x77, 0x42, 0xa5, 0x15, 0xae, 0x21, 0x9c, 0x62, 0xae, 0xc3, 0xdc, 0x9d, 0x81, 0xa6, 0x35, 0xa1,
+	0x92, 0x1b, 0x56, 0x17, 0xd0, 0xf9, 0x3e, 0xa5, 0x34, 0xb1, 0x03, 0xbb, 0x8c, 0xd2, 0x05, 0xe9,
+	0xa6, 0x64, 0x8e, 0x39, 0xa3, 0x38, 0x73, 0xcf, 0x45, 0x13, 0xc8, 0x7d, 0xf0, 0x95, 0x6f, 0xea,
+	0x09, 0x34, 0x6e, 0x6c, 0x78, 0xab, 0xfd, 0xad, 0x7e, 0x58, 0x44, 0xb3, 0x73, 0x13, 0xde, 0x72,
+	0x3b, 0x36, 0xa5, 0x28, 0x99, 0x66, 0x13, 0xdd, 0xda, 0x6a, 0xe7, 0xce, 0x40, 0xd3, 0xfa, 0x50,
+	0x9d, 0xd4, 0x7b, 0x50, 0x49, 0xbe, 0x18, 0xa7, 0x64, 0xed, 0x22, 0x4b, 0xc8, 0x15, 0x71, 0xee,
+	0x48, 0xef, 0x4a, 0xf0, 0x94, 0x83, 0xc7, 0xf2, 0xfb, 0x3c, 0x40, 0xd0, 0x1c, 0x56, 0xe2, 0x97,
+	0x95, 0xa4, 0x06, 0xd0, 0x8e, 0xa3, 0x19, 0xa5, 0x97, 0x51, 0x41, 0xba, 0x2d, 0x25, 0x8e, 0xb8,
+	0xc4, 0x3e, 0x97, 0x58, 0x39, 0x68, 0xd6, 0x14, 0x8f, 0xe5, 0xea, 0xfa, 0xfa, 0xd3, 0x67, 0x72,
+	0x4b, 0x72, 0x1a, 0xb6, 0xc6, 0xb2, 0xb6, 0xd0, 0x6c, 0x70, 0xea, 0x1c, 0xfc, 0xf8, 0x47, 0x3e,
+	0x8d, 0x49, 0x07, 0x92, 0x38, 0xe4, 0x44, 0x20, 0xaf, 0x88, 0x8c, 0x57, 0xff, 0x99, 0x9a, 0x50,
+	0x2f, 0xa0, 0x25, 0x1b, 0x60, 0x43, 0xbd, 0x77, 0xe6, 0xf5, 0x9a, 0xa3, 0x47, 0x0c, 0xef, 0xad,
+	0x36, 0xc4, 0x86, 0x42, 0xcb, 0x39, 0x54, 0x6f, 0xc1, 0x4f, 0x88, 0xe6, 0xe4, 0x74, 0xe7, 0xcc,
+	0xeb, 0x05, 0xe1, 0x69, 0x9f, 0x77, 0xb0, 0x2f, 0xab, 0xd6, 0x9f, 0x66, 0x19, 0xb9, 0x7e, 0xe5,
+	0xdb, 0x3c, 0xa3, 0x8d, 0x77, 0x2b, 0x11, 0x4d, 0x9d, 0xee, 0x7e, 0x83, 0xa6, 0xd0, 0xea, 0x1c,
+	0x5a, 0x32, 0x48, 0x1b, 0xd6, 0x0b, 0xba, 0x7e, 0xbe, 0xd6, 0xd1, 0xf8, 0x72, 0x0a, 0xbb, 0xcf,
+	0x01, 0xaa, 0x38, 0x17, 0x57, 0x27, 0xe0, 0x2d, 0xeb, 0x4c, 0xc0, 0x19, 0x5f, 0x32, 0x68, 0xbc,
+	0x65, 0xf7, 0x29, 0xf8, 0x97, 0xd5, 0xb7, 0x3b, 0x01, 0x2f, 0x7e, 0x00, 0xc5, 0x68, 0xbc, 0x78,
+	0xd4, 0x05, 0xbf, 0x28, 0x1d, 0xcf, 0x78, 0xdd, 0x66, 0x25, 0x20, 0xbe, 0x03, 0x48, 0xf3, 0x9f,
+	0xe4, 0x6c, 0xcc, 0x43, 0xb8, 0x80, 0x4e, 0x91, 0xcf, 0xc8, 0x0e, 0x93, 0x7a, 0x25, 0xbd, 0xad,
+	0x95, 0xbc, 0xe7, 0xa2, 0x09, 0xf8, 0x3e, 0x4c, 0x64, 0x25, 0xc7, 0xbe, 0xfc, 0x21, 0x87, 0xff,
+	0x06, 0x00, 0xd9, 0xb9, 0x7f, 0x4e, 0xba, 0x03, 0x00, 0x00,
+}